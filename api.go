@@ -7,6 +7,7 @@ import (
 	"fmt"
 	"io"
 	"log"
+	"log/slog"
 	"net/http"
 	"net/http/httputil"
 	"net/url"
@@ -29,8 +30,51 @@ type API struct {
 	Key    string
 	Client *http.Client
 
-	User  string
-	Debug bool
+	// AccessToken is an OAuth2 token used instead of Key when set.
+	AccessToken string
+
+	User      string
+	UserAgent string
+	Debug     bool
+
+	// Retry is the policy applied to failed calls. Nil disables retries.
+	Retry *RetryPolicy
+
+	// Limiter caps concurrent requests made through this API. Nil means
+	// no limit.
+	Limiter *Limiter
+
+	// Middleware wraps every call, the first one being the outermost.
+	Middleware []Middleware
+
+	// Metrics receives measurements of every call. Nil disables them.
+	Metrics Metrics
+
+	// MaxResponseBytes makes calls fail with ErrResponseTooLarge when the
+	// response body is bigger, once decompressed. Zero means no limit.
+	MaxResponseBytes int64
+
+	// Compression enables gzip for requests and responses. Nil disables it.
+	Compression *Compression
+
+	// StrictDecoding makes calls fail with an UnknownFieldsError when the
+	// response has fields the structs don't model, which is meant to catch
+	// drift in tests. The response is still decoded.
+	StrictDecoding bool
+
+	// DisableValidation skips the Validate check of request bodies, leaving
+	// it to Mailchimp.
+	DisableValidation bool
+
+	// Logger receives a record for every round trip, and the bodies at
+	// debug level. Nil disables logging.
+	Logger *slog.Logger
+	// LogRedact lists the JSON fields and query params whose values are
+	// masked in logs. Defaults to DefaultLogRedact when nil.
+	LogRedact []string
+	// LogBodyLimit caps the number of bytes logged per body. Defaults to
+	// DefaultLogBodyLimit.
+	LogBodyLimit int
 
 	endpoint string
 }
@@ -77,7 +121,8 @@ type Mailchimp interface {
 
 var _ Mailchimp = &API{}
 
-// New creates a API
+// New creates a API. See NewWithOptions for validating the key and
+// overriding the endpoint.
 func New(apiKey string, client *http.Client) *API {
 	u := url.URL{}
 	u.Scheme = "https"
@@ -93,41 +138,142 @@ func New(apiKey string, client *http.Client) *API {
 		Key:      apiKey,
 		endpoint: u.String(),
 		Client:   client,
+		Limiter:  NewLimiter(MaxConnections, 0, 0),
 	}
 }
 
+// call holds everything needed to send, and resend, a single operation.
+type call struct {
+	op   *Operation
+	path string
+	url  string
+	body []byte
+
+	// payload is body as sent, compressed according to encoding.
+	payload  []byte
+	encoding string
+}
+
 // Request will make a call to the actual API.
 func (api *API) request(ctx context.Context, method, path string, params QueryParams, body, response interface{}) error {
-	requestURL := fmt.Sprintf("%s%s", api.endpoint, path)
+	return api.do(ctx, newOperation("", method, path), params, body, response)
+}
+
+// execute is the innermost Handler, sending op and retrying it as needed.
+func (api *API) execute(ctx context.Context, op *Operation) error {
+	c := &call{op: op, path: op.Path()}
+	c.url = fmt.Sprintf("%s%s", api.endpoint, c.path)
 	if api.Debug {
-		log.Printf("Requesting %s: %s\n", method, requestURL)
+		log.Printf("Requesting %s: %s\n", op.Method, c.url)
 	}
 
-	var bodyBytes io.Reader
 	var err error
-	var data []byte
-	if body != nil {
-		data, err = json.Marshal(body)
+	if op.Body != nil {
+		c.body, err = json.Marshal(op.Body)
+		if err != nil {
+			return err
+		}
+		if api.Debug {
+			log.Printf("Adding body: %+v\n", op.Body)
+		}
+		api.logBody(ctx, c, "mailchimp request body", c.body)
+
+		c.payload, c.encoding, err = api.Compression.encode(c.body)
 		if err != nil {
 			return err
 		}
-		bodyBytes = bytes.NewBuffer(data)
+	}
+
+	start := time.Now()
+	for attempt := 1; ; attempt++ {
+		var resp *http.Response
+		resp, err = api.attempt(ctx, c)
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+
+		wait, retry := api.Retry.next(ctx, op.Method, attempt, resp, err)
+		api.logAttempt(ctx, c, attempt, status, err, retry, wait)
+		api.observeAttempt(op, status, retry)
+		if !retry {
+			api.observeCall(op, status, time.Since(start), err)
+			return err
+		}
+
 		if api.Debug {
-			log.Printf("Adding body: %+v\n", body)
+			log.Printf("Retrying %s %s in %s after attempt %d: %s\n", op.Method, c.url, wait, attempt, err)
+		}
+
+		if sleepErr := sleep(ctx, wait); sleepErr != nil {
+			api.observeCall(op, status, time.Since(start), err)
+			return err
+		}
+	}
+}
+
+// attempt makes a single round trip, holding a Limiter slot until the
+// response body has been consumed.
+func (api *API) attempt(ctx context.Context, c *call) (*http.Response, error) {
+	if api.Limiter != nil {
+		wait, err := api.Limiter.Acquire(ctx)
+		c.op.Info.QueueWait += wait
+		if err != nil {
+			return nil, err
+		}
+		defer api.Limiter.Release()
+
+		if api.Debug && wait > 0 {
+			log.Printf("Waited %s for a free connection\n", wait)
 		}
 	}
 
-	req, err := http.NewRequestWithContext(ctx, method, requestURL, bodyBytes)
+	start := time.Now()
+	resp, err := api.send(ctx, c)
 	if err != nil {
-		return err
+		c.op.Info.record(nil, time.Since(start))
+		return nil, err
+	}
+
+	err = api.handleResponse(ctx, c, resp)
+	c.op.Info.record(resp, time.Since(start))
+
+	return resp, err
+}
+
+// send builds a fresh request for every attempt, as the body reader is
+// consumed by the previous one.
+func (api *API) send(ctx context.Context, c *call) (*http.Response, error) {
+	var bodyBytes io.Reader
+	if c.payload != nil {
+		bodyBytes = bytes.NewReader(c.payload)
 	}
 
+	req, err := http.NewRequestWithContext(ctx, c.op.Method, c.url, bodyBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	for k, v := range c.op.Header {
+		req.Header[k] = v
+	}
 	req.Header.Set("Content-Type", "application/json")
-	req.SetBasicAuth(api.User, api.Key)
+	if c.encoding != "" {
+		req.Header.Set("Content-Encoding", c.encoding)
+	}
+	api.Compression.acceptEncoding(req)
+	if api.UserAgent != "" {
+		req.Header.Set("User-Agent", api.UserAgent)
+	}
+	if api.AccessToken != "" {
+		req.Header.Set("Authorization", "Bearer "+api.AccessToken)
+	} else {
+		req.SetBasicAuth(api.User, api.Key)
+	}
 
-	if params != nil && !reflect.ValueOf(params).IsNil() {
+	if c.op.Params != nil && !reflect.ValueOf(c.op.Params).IsNil() {
 		queryParams := req.URL.Query()
-		for k, v := range params.Params() {
+		for k, v := range c.op.Params.Params() {
 			if v != "" {
 				queryParams.Set(k, v)
 			}
@@ -140,42 +286,84 @@ func (api *API) request(ctx context.Context, method, path string, params QueryPa
 	}
 
 	if api.Debug {
-		dump, _ := httputil.DumpRequestOut(req, true)
-		log.Printf("%s", string(dump))
+		log.Printf("%s", dumpRequest(req, c.body))
 	}
 
-	resp, err := api.Client.Do(req)
+	return api.Client.Do(req)
+}
+
+// handleResponse reads and closes the body of resp, decoding it into
+// c.op.Response on success or into an error otherwise. Successful bodies
+// are decoded as they are read unless they need to be logged.
+func (api *API) handleResponse(ctx context.Context, c *call, resp *http.Response) error {
+	defer resp.Body.Close()
+
+	body, zr, err := decodeBody(resp)
 	if err != nil {
 		return err
 	}
-	defer resp.Body.Close()
+	defer zr.Close()
 
-	if api.Debug {
-		dump, _ := httputil.DumpResponse(resp, true)
-		log.Printf("%s", string(dump))
+	if api.MaxResponseBytes > 0 {
+		body = &limitedBody{r: body, n: api.MaxResponseBytes}
+	}
+
+	success := resp.StatusCode >= 200 && resp.StatusCode < 300
+	// Do not unmarshall response is nil
+	hasResponse := c.op.Response != nil && !reflect.ValueOf(c.op.Response).IsNil()
+
+	if success && hasResponse && !api.logsBodies(ctx) {
+		if err := decodeResponse(body, c.op.Response); err != nil {
+			return err
+		}
+		// Drain what's left so the connection can be reused.
+		if _, err = io.Copy(io.Discard, body); err != nil {
+			return err
+		}
+		return api.checkUnknownFields(c.op.Response)
 	}
 
-	data, err = io.ReadAll(resp.Body)
+	data, err := io.ReadAll(body)
 	if err != nil {
 		return err
 	}
 
-	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
-		// Do not unmarshall response is nil
-		if response == nil || reflect.ValueOf(response).IsNil() || len(data) == 0 {
+	if api.Debug {
+		dump, _ := httputil.DumpResponse(resp, false)
+		log.Printf("%s%s", string(dump), string(data))
+	}
+	api.logBody(ctx, c, "mailchimp response body", data)
+
+	if success {
+		if !hasResponse {
 			return nil
 		}
-
-		err = json.Unmarshal(data, response)
-		if err != nil {
+		if err := decodeResponse(bytes.NewReader(data), c.op.Response); err != nil {
 			return err
 		}
+		return api.checkUnknownFields(c.op.Response)
+	}
 
+	// This is an API Error
+	return parseAPIError(resp, data)
+}
+
+// checkUnknownFields fails with an UnknownFieldsError in strict mode when
+// the decoded response has fields the structs don't model.
+func (api *API) checkUnknownFields(response interface{}) error {
+	if !api.StrictDecoding {
 		return nil
 	}
+	if fields := unknownFields(response); len(fields) > 0 {
+		return &UnknownFieldsError{Fields: fields}
+	}
+	return nil
+}
 
-	// This is an API Error
-	return parseAPIError(data)
+// logsBodies reports whether response bodies are logged, and so need to be
+// read whole before being decoded.
+func (api *API) logsBodies(ctx context.Context) bool {
+	return api.Debug || (api.Logger != nil && api.Logger.Enabled(ctx, slog.LevelDebug))
 }
 
 // requestOk Make Request ignoring body and return true if HTTP status code is 2xx.
@@ -186,13 +374,3 @@ func (api *API) requestOk(ctx context.Context, method, path string) (bool, error
 	}
 	return true, nil
 }
-
-func parseAPIError(data []byte) error {
-	apiError := new(APIError)
-	err := json.Unmarshal(data, apiError)
-	if err != nil {
-		return err
-	}
-
-	return apiError
-}
@@ -62,7 +62,7 @@ func TestGoodGet(t *testing.T) {
 
 		// return something
 		data, _ := json.Marshal(expected)
-		fmt.Fprintf(w, string(data))
+		fmt.Fprint(w, string(data))
 	}
 
 	api := testAPI()
@@ -97,7 +97,7 @@ func TestGetWithParams(t *testing.T) {
 			}
 		}
 		data, _ := json.Marshal(expected)
-		fmt.Fprintf(w, string(data))
+		fmt.Fprint(w, string(data))
 	}
 
 	api := testAPI()
@@ -0,0 +1,155 @@
+package gochimp3
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// BatchBuilder builds a BatchOperationCreationRequest from the request types
+// of the rest of the API, giving every operation a unique OperationID:
+//
+//	batch := gochimp3.NewBatchBuilder()
+//	batch.AddOrUpdateMember(listID, &gochimp3.MemberRequest{
+//		EmailAddress: "a@example.com",
+//		StatusIfNew:  "subscribed",
+//	})
+//	batch.UpdateTags(listID, "a@example.com", []gochimp3.UpdateMemberTag{{Name: "vip", Status: "active"}})
+//
+//	req, err := batch.Request()
+//	if err != nil { ... }
+//	resp, err := client.CreateBatchOperation(ctx, req)
+//
+// Bodies are validated as they're added, whatever API.DisableValidation is,
+// and the first error is returned by Request. Pass a json.RawMessage body to
+// Add to skip validation.
+type BatchBuilder struct {
+	prefix     string
+	operations []BatchOperation
+	err        error
+}
+
+// NewBatchBuilder returns an empty BatchBuilder whose OperationIDs don't
+// collide with those of other builders.
+func NewBatchBuilder() *BatchBuilder {
+	b := make([]byte, 4)
+	rand.Read(b)
+	return &BatchBuilder{prefix: hex.EncodeToString(b)}
+}
+
+// Add appends an operation on any endpoint and returns its OperationID. path
+// is relative to the API root, see JoinPath to build it. body is marshaled to
+// JSON unless nil.
+func (b *BatchBuilder) Add(method, path string, params QueryParams, body interface{}) string {
+	id := b.prefix + "-" + strconv.Itoa(len(b.operations))
+	op := BatchOperation{
+		Method:      method,
+		Path:        path,
+		OperationID: id,
+	}
+
+	if params != nil {
+		op.Params = make(url.Values)
+		for k, v := range params.Params() {
+			if v != "" {
+				op.Params.Set(k, v)
+			}
+		}
+	}
+
+	if body != nil {
+		if err := validate(method, body); err != nil {
+			b.fail(id, err)
+		}
+		data, err := json.Marshal(body)
+		if err != nil {
+			b.fail(id, err)
+		}
+		op.Body = string(data)
+	}
+
+	b.operations = append(b.operations, op)
+	return id
+}
+
+func (b *BatchBuilder) fail(id string, err error) {
+	if b.err == nil {
+		b.err = fmt.Errorf("gochimp3: batch operation %s: %w", id, err)
+	}
+}
+
+// AddOrUpdateMember adds a PUT of body to the list's member with body's
+// email address.
+func (b *BatchBuilder) AddOrUpdateMember(listID string, body *MemberRequest) string {
+	if body == nil || body.EmailAddress == "" {
+		id := b.Add("PUT", "", nil, nil)
+		b.fail(id, errors.New("email address is required"))
+		return id
+	}
+
+	return b.addEndpoint("PUT", body, single_member_path, listID, batchMemberID(body.EmailAddress))
+}
+
+// UpdateTags adds the given tag changes to a list member. member is an email
+// address or a member ID.
+func (b *BatchBuilder) UpdateTags(listID, member string, tags []UpdateMemberTag) string {
+	return b.addEndpoint("POST", memberTagsRequest{Tags: tags}, member_tags_path, listID, batchMemberID(member))
+}
+
+// DeleteMember adds the archiving of a list member. member is an email
+// address or a member ID.
+func (b *BatchBuilder) DeleteMember(listID, member string) string {
+	return b.addEndpoint("DELETE", nil, single_member_path, listID, batchMemberID(member))
+}
+
+// ModifySegment adds members to and removes members from a static segment.
+func (b *BatchBuilder) ModifySegment(listID, segmentID string, body *SegmentBatchRequest) string {
+	return b.addEndpoint("POST", body, single_segment_path, listID, segmentID)
+}
+
+// addEndpoint adds an operation on one of the path templates of the API,
+// checking its arguments like API calls do. body is nil for operations
+// without one, and otherwise mustn't be a nil pointer.
+func (b *BatchBuilder) addEndpoint(method string, body interface{}, template string, args ...string) string {
+	op := newOperation("", method, template, args...)
+	err := op.checkPathArgs()
+	if v := reflect.ValueOf(body); err == nil && v.Kind() == reflect.Pointer && v.IsNil() {
+		err = errors.New("body is required")
+	}
+	if err != nil {
+		id := b.Add(method, "", nil, nil)
+		b.fail(id, err)
+		return id
+	}
+	return b.Add(method, op.Path(), nil, body)
+}
+
+// Len returns the number of operations added so far.
+func (b *BatchBuilder) Len() int {
+	return len(b.operations)
+}
+
+// Request returns the operations added so far, or the first error met while
+// adding them.
+func (b *BatchBuilder) Request() (*BatchOperationCreationRequest, error) {
+	if b.err != nil {
+		return nil, b.err
+	}
+	return &BatchOperationCreationRequest{Operations: append([]BatchOperation(nil), b.operations...)}, nil
+}
+
+// batchMemberID returns the subscriber hash of member when it's an email
+// address, and member itself otherwise.
+func batchMemberID(member string) string {
+	if !strings.Contains(member, "@") {
+		return member
+	}
+	id, _ := EmailToMemberID(member)
+	return id
+}
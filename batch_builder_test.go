@@ -0,0 +1,84 @@
+package gochimp3
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchBuilder(t *testing.T) {
+	batch := NewBatchBuilder()
+	hash, _ := EmailToMemberID("A@example.com")
+
+	ids := []string{
+		batch.AddOrUpdateMember("l1", &MemberRequest{EmailAddress: "A@example.com", Status: "subscribed"}),
+		batch.UpdateTags("l1", "A@example.com", []UpdateMemberTag{{Name: "vip", Status: "active"}}),
+		batch.DeleteMember("l1", hash),
+		batch.ModifySegment("l1", "s/1", &SegmentBatchRequest{MembersToAdd: []string{"b@example.com"}, MembersToRemove: []string{}}),
+		batch.Add("GET", JoinPath("reports", "c1"), Query{"fields": "opens"}, nil),
+	}
+	assert.Equal(t, 5, batch.Len())
+
+	req, err := batch.Request()
+	fatalIf(t, err)
+	fatalIf(t, req.Validate())
+
+	ops := req.Operations
+	for i, op := range ops {
+		assert.Equal(t, ids[i], op.OperationID)
+	}
+	assert.Len(t, map[string]bool{ids[0]: true, ids[1]: true, ids[2]: true, ids[3]: true, ids[4]: true}, 5)
+
+	assert.Equal(t, "PUT", ops[0].Method)
+	assert.Equal(t, "/lists/l1/members/"+hash, ops[0].Path)
+	assert.JSONEq(t, `{"email_address":"A@example.com","status":"subscribed","language":"","vip":false}`, ops[0].Body)
+
+	assert.Equal(t, "/lists/l1/members/"+hash+"/tags", ops[1].Path)
+	assert.JSONEq(t, `{"tags":[{"name":"vip","status":"active"}]}`, ops[1].Body)
+
+	assert.Equal(t, "DELETE", ops[2].Method)
+	assert.Equal(t, "/lists/l1/members/"+hash, ops[2].Path)
+	assert.Equal(t, "", ops[2].Body)
+
+	assert.Equal(t, "/lists/l1/segments/s%2F1", ops[3].Path)
+	assert.Equal(t, "/reports/c1", ops[4].Path)
+	assert.Equal(t, "opens", ops[4].Params.Get("fields"))
+
+	assert.NotEqual(t, ids[0], NewBatchBuilder().DeleteMember("l1", hash))
+}
+
+func TestBatchBuilderErrors(t *testing.T) {
+	batch := NewBatchBuilder()
+	batch.ModifySegment("l1", "s1", &SegmentBatchRequest{})
+	batch.AddOrUpdateMember("l1", &MemberRequest{Status: "subscribed"})
+
+	_, err := batch.Request()
+	assert.ErrorIs(t, err, ErrInvalidResource)
+
+	batch = NewBatchBuilder()
+	batch.AddOrUpdateMember("l1", &MemberRequest{Status: "subscribed"})
+	_, err = batch.Request()
+	assert.EqualError(t, err, "gochimp3: batch operation "+batch.prefix+"-0: email address is required")
+
+	batch = NewBatchBuilder()
+	batch.AddOrUpdateMember("l1", nil)
+	_, err = batch.Request()
+	assert.EqualError(t, err, "gochimp3: batch operation "+batch.prefix+"-0: email address is required")
+
+	batch = NewBatchBuilder()
+	batch.ModifySegment("l1", "s1", nil)
+	_, err = batch.Request()
+	assert.EqualError(t, err, "gochimp3: batch operation "+batch.prefix+"-0: body is required")
+
+	batch = NewBatchBuilder()
+	batch.DeleteMember("..", "a@example.com")
+	_, err = batch.Request()
+	assert.ErrorIs(t, err, ErrInvalidPathArg)
+
+	batch = NewBatchBuilder()
+	batch.Add("POST", "/lists/l1/segments/s1", nil, json.RawMessage(`{"members_to_add":["a@example.com"]}`))
+	req, err := batch.Request()
+	fatalIf(t, err)
+	assert.Equal(t, `{"members_to_add":["a@example.com"]}`, req.Operations[0].Body)
+}
@@ -0,0 +1,180 @@
+package gochimp3
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log"
+	"net/http"
+	"strings"
+)
+
+// ErrNoBatchResults is returned by GetBatchResults for a batch that hasn't
+// finished yet.
+var ErrNoBatchResults = errors.New("gochimp3: batch has no results yet")
+
+// BatchOperationResult is the outcome of one operation of a finished batch.
+type BatchOperationResult struct {
+	// OperationID is the BatchOperation.OperationID of the operation.
+	OperationID string
+	StatusCode  int
+
+	// Response is the body Mailchimp returned for the operation.
+	Response json.RawMessage
+
+	// Err is set when StatusCode isn't 2xx, usually to an *APIError.
+	Err error
+}
+
+// Decode unmarshals the response of a successful operation into v, or
+// returns its Err.
+func (r *BatchOperationResult) Decode(v interface{}) error {
+	if r.Err != nil {
+		return r.Err
+	}
+	if len(r.Response) == 0 {
+		return nil
+	}
+	return json.Unmarshal(r.Response, v)
+}
+
+// GetBatchResults downloads the results archive of a finished batch and
+// calls fn with the result of every operation as it's read, stopping at the
+// first error fn returns:
+//
+//	err := client.GetBatchResults(ctx, batch, func(r *gochimp3.BatchOperationResult) error {
+//		if r.Err != nil {
+//			log.Printf("%s failed: %v", r.OperationID, r.Err)
+//		}
+//		return nil
+//	})
+//
+// The archive is fetched from batch.ResponseBodyUrl with API.Client, without
+// the API's credentials. As it isn't a call to the API, only API.Retry and
+// API.UserAgent apply to it: the Limiter, Middleware, Metrics, Logger and
+// MaxResponseBytes don't. Only fetching the archive is retried, not reading
+// it once fn has been called.
+func (api *API) GetBatchResults(ctx context.Context, batch *BatchOperationResponse, fn func(*BatchOperationResult) error) error {
+	if batch.ResponseBodyUrl == "" {
+		return ErrNoBatchResults
+	}
+
+	var resp *http.Response
+	var err error
+	for attempt := 1; ; attempt++ {
+		resp, err = api.fetchBatchResults(ctx, batch.ResponseBodyUrl)
+
+		wait, retry := api.Retry.next(ctx, "GET", attempt, resp, err)
+		if !retry {
+			break
+		}
+		if api.Debug {
+			log.Printf("Retrying GET of batch results in %s after attempt %d: %s\n", wait, attempt, err)
+		}
+		if sleepErr := sleep(ctx, wait); sleepErr != nil {
+			return err
+		}
+	}
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	body := io.Reader(resp.Body)
+	if !resp.Uncompressed {
+		zr, err := gzip.NewReader(resp.Body)
+		if err != nil {
+			return err
+		}
+		defer zr.Close()
+		body = zr
+	}
+
+	return readBatchResults(body, fn)
+}
+
+// fetchBatchResults requests the results archive at url. On a non-2xx
+// response it closes the body and returns the response along with an
+// *HTTPError, for the RetryPolicy to look at.
+func (api *API) fetchBatchResults(ctx context.Context, url string) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	if api.UserAgent != "" {
+		req.Header.Set("User-Agent", api.UserAgent)
+	}
+
+	resp, err := api.Client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		defer resp.Body.Close()
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 4096))
+		return resp, &HTTPError{StatusCode: resp.StatusCode, Header: resp.Header, Body: data}
+	}
+
+	return resp, nil
+}
+
+// readBatchResults reads the tar archive of a batch's results, made of JSON
+// files each holding an array of operation results.
+func readBatchResults(r io.Reader, fn func(*BatchOperationResult) error) error {
+	tr := tar.NewReader(r)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if hdr.Typeflag != tar.TypeReg || !strings.HasSuffix(hdr.Name, ".json") {
+			continue
+		}
+
+		if err := readBatchResultsFile(tr, fn); err != nil {
+			return err
+		}
+	}
+}
+
+func readBatchResultsFile(r io.Reader, fn func(*BatchOperationResult) error) error {
+	dec := json.NewDecoder(r)
+	if err := expectDelim(dec, '['); err != nil {
+		return err
+	}
+
+	for dec.More() {
+		var raw struct {
+			StatusCode  int    `json:"status_code"`
+			OperationID string `json:"operation_id"`
+			Response    string `json:"response"`
+		}
+		if err := dec.Decode(&raw); err != nil {
+			return err
+		}
+
+		result := &BatchOperationResult{
+			OperationID: raw.OperationID,
+			StatusCode:  raw.StatusCode,
+		}
+		if raw.Response != "" {
+			result.Response = json.RawMessage(raw.Response)
+		}
+		if raw.StatusCode < 200 || raw.StatusCode >= 300 {
+			result.Err = parseAPIError(&http.Response{StatusCode: raw.StatusCode}, []byte(raw.Response))
+		}
+
+		if err := fn(result); err != nil {
+			return err
+		}
+	}
+
+	return expectDelim(dec, ']')
+}
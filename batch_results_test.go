@@ -0,0 +1,142 @@
+package gochimp3
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// batchArchive builds a results archive like Mailchimp's, one file per
+// slice of results.
+func batchArchive(t *testing.T, files ...[]map[string]interface{}) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+
+	fatalIf(t, tw.WriteHeader(&tar.Header{Name: "results/", Typeflag: tar.TypeDir, Mode: 0755}))
+	for i, results := range files {
+		data, err := json.Marshal(results)
+		fatalIf(t, err)
+		fatalIf(t, tw.WriteHeader(&tar.Header{Name: "results/" + string(rune('a'+i)) + ".json", Mode: 0644, Size: int64(len(data))}))
+		_, err = tw.Write(data)
+		fatalIf(t, err)
+	}
+
+	fatalIf(t, tw.Close())
+	fatalIf(t, zw.Close())
+	return buf.Bytes()
+}
+
+func TestGetBatchResults(t *testing.T) {
+	batch := NewBatchBuilder()
+	added := batch.AddOrUpdateMember("l1", &MemberRequest{EmailAddress: "a@example.com", Status: "subscribed"})
+	exists := batch.AddOrUpdateMember("l1", &MemberRequest{EmailAddress: "b@example.com", Status: "subscribed"})
+	deleted := batch.DeleteMember("l1", "c@example.com")
+
+	archive := batchArchive(t,
+		[]map[string]interface{}{
+			{"status_code": 200, "operation_id": added, "response": `{"id":"m1","email_address":"a@example.com"}`},
+			{"status_code": 400, "operation_id": exists, "response": `{"title":"Member Exists","status":400,"detail":"b@example.com is already a list member."}`},
+		},
+		[]map[string]interface{}{
+			{"status_code": 204, "operation_id": deleted, "response": ""},
+		},
+	)
+
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Empty(t, r.Header.Get("Authorization"))
+		w.Header().Set("Content-Type", "application/x-gzip")
+		w.Write(archive)
+	}))
+	defer srv.Close()
+
+	api := New("apikey-us1", nil)
+
+	results := make(map[string]*BatchOperationResult)
+	err := api.GetBatchResults(t.Context(), &BatchOperationResponse{ResponseBodyUrl: srv.URL + "/results.tar.gz"}, func(r *BatchOperationResult) error {
+		results[r.OperationID] = r
+		return nil
+	})
+	fatalIf(t, err)
+	assert.Len(t, results, 3)
+
+	var member Member
+	fatalIf(t, results[added].Decode(&member))
+	assert.Equal(t, "m1", member.ID)
+
+	assert.ErrorIs(t, results[exists].Err, ErrMemberExists)
+	assert.ErrorIs(t, results[exists].Decode(&member), ErrMemberExists)
+
+	assert.Equal(t, 204, results[deleted].StatusCode)
+	assert.NoError(t, results[deleted].Decode(&member))
+
+	err = api.GetBatchResults(t.Context(), &BatchOperationResponse{}, nil)
+	assert.ErrorIs(t, err, ErrNoBatchResults)
+}
+
+func TestGetBatchResultsErrors(t *testing.T) {
+	archive := batchArchive(t, []map[string]interface{}{
+		{"status_code": 200, "operation_id": "a", "response": `{}`},
+		{"status_code": 200, "operation_id": "b", "response": `{}`},
+	})
+
+	var statuses []int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		status := http.StatusOK
+		if len(statuses) > 0 {
+			status, statuses = statuses[0], statuses[1:]
+		}
+		if status != http.StatusOK {
+			w.WriteHeader(status)
+			w.Write([]byte(`<Error><Code>AccessDenied</Code></Error>`))
+			return
+		}
+		w.Write(archive)
+	}))
+	defer srv.Close()
+
+	api := New("apikey-us1", nil)
+	batch := &BatchOperationResponse{ResponseBodyUrl: srv.URL + "/results.tar.gz"}
+	count := func(n *int) func(*BatchOperationResult) error {
+		return func(*BatchOperationResult) error { *n++; return nil }
+	}
+
+	var n int
+	statuses = []int{http.StatusForbidden}
+	err := api.GetBatchResults(t.Context(), batch, count(&n))
+	var httpErr *HTTPError
+	if assert.True(t, errors.As(err, &httpErr), "%v", err) {
+		assert.Equal(t, http.StatusForbidden, httpErr.StatusCode)
+		assert.Contains(t, string(httpErr.Body), "AccessDenied")
+	}
+	assert.Equal(t, 0, n)
+
+	// 5xx responses are retried following api.Retry.
+	api.Retry = &RetryPolicy{MaxAttempts: 2}
+	statuses = []int{http.StatusServiceUnavailable}
+	fatalIf(t, api.GetBatchResults(t.Context(), batch, count(&n)))
+	assert.Equal(t, 2, n)
+	assert.Empty(t, statuses)
+
+	statuses = []int{http.StatusServiceUnavailable, http.StatusServiceUnavailable}
+	err = api.GetBatchResults(t.Context(), batch, count(&n))
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusServiceUnavailable, httpErr.StatusCode)
+
+	// An error from fn stops the iteration and is returned.
+	stop := errors.New("stop")
+	calls := 0
+	err = api.GetBatchResults(t.Context(), batch, func(r *BatchOperationResult) error {
+		calls++
+		return stop
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 1, calls)
+}
@@ -0,0 +1,175 @@
+package gochimp3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+// Defaults of SubmitOptions.
+const (
+	DefaultBatchMaxOperations = 1000
+	DefaultBatchMaxBytes      = 8 << 20
+	DefaultBatchMaxInFlight   = 10
+)
+
+// SubmitOptions controls how SubmitOperations splits operations into
+// batches. A nil *SubmitOptions uses the defaults.
+type SubmitOptions struct {
+	// MaxOperations and MaxBytes cap the number of operations of a batch and
+	// the size of its request body.
+	MaxOperations int
+	MaxBytes      int
+
+	// MaxInFlight caps the number of submitted batches that haven't
+	// finished. Once reached, the oldest one is waited for before the next
+	// is submitted.
+	MaxInFlight int
+
+	// Wait controls the polling of batches in flight.
+	Wait *WaitOptions
+}
+
+// BatchSubmission is the set of batches created by SubmitOperations.
+type BatchSubmission struct {
+	// Batches are the submitted batches in order, as last polled.
+	Batches []*BatchOperationResponse
+
+	api *API
+}
+
+// SubmitOperations creates as many batches as needed to run ops within the
+// limits of opts, in order:
+//
+//	sub, err := client.SubmitOperations(ctx, req.Operations, nil)
+//	if err != nil { ... }
+//	err = sub.Results(ctx, nil, func(r *gochimp3.BatchOperationResult) error {
+//		...
+//	})
+//
+// When a batch can't be created, the error is returned along with the
+// batches submitted before it.
+func (api *API) SubmitOperations(ctx context.Context, ops []BatchOperation, opts *SubmitOptions) (*BatchSubmission, error) {
+	if opts == nil {
+		opts = &SubmitOptions{}
+	}
+	maxInFlight := opts.MaxInFlight
+	if maxInFlight <= 0 {
+		maxInFlight = DefaultBatchMaxInFlight
+	}
+
+	chunks, err := chunkOperations(ops, opts.MaxOperations, opts.MaxBytes)
+	if err != nil {
+		return nil, err
+	}
+
+	sub := &BatchSubmission{api: api}
+	var inFlight []int
+	for _, chunk := range chunks {
+		for len(inFlight) >= maxInFlight {
+			if err := sub.wait(ctx, inFlight[0], opts.Wait); err != nil {
+				return sub, err
+			}
+			inFlight = inFlight[1:]
+		}
+
+		batch, err := api.CreateBatchOperation(ctx, &BatchOperationCreationRequest{Operations: chunk})
+		if err != nil {
+			return sub, err
+		}
+		sub.Batches = append(sub.Batches, batch)
+		if batch.Status == "finished" {
+			api.observeBatch(batch, false)
+		} else {
+			inFlight = append(inFlight, len(sub.Batches)-1)
+		}
+	}
+
+	return sub, nil
+}
+
+// IDs returns the IDs of the submitted batches.
+func (sub *BatchSubmission) IDs() []string {
+	ids := make([]string, len(sub.Batches))
+	for i, batch := range sub.Batches {
+		ids[i] = batch.ID
+	}
+	return ids
+}
+
+// Wait waits for every batch to finish, see WaitForBatch.
+func (sub *BatchSubmission) Wait(ctx context.Context, opts *WaitOptions) error {
+	for i := range sub.Batches {
+		if err := sub.wait(ctx, i, opts); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Results waits for every batch to finish and calls fn with the results of
+// all their operations, see GetBatchResults.
+func (sub *BatchSubmission) Results(ctx context.Context, opts *WaitOptions, fn func(*BatchOperationResult) error) error {
+	for i := range sub.Batches {
+		if err := sub.wait(ctx, i, opts); err != nil {
+			return err
+		}
+		if err := sub.api.GetBatchResults(ctx, sub.Batches[i], fn); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (sub *BatchSubmission) wait(ctx context.Context, i int, opts *WaitOptions) error {
+	if sub.Batches[i].Status == "finished" {
+		return nil
+	}
+
+	batch, err := sub.api.waitForBatch(ctx, sub.Batches[i].ID, opts, sub.Batches[i])
+	if batch != nil {
+		sub.Batches[i] = batch
+	}
+	return err
+}
+
+// chunkOperations splits ops into slices of at most maxOps operations whose
+// request body is at most maxBytes long.
+func chunkOperations(ops []BatchOperation, maxOps, maxBytes int) ([][]BatchOperation, error) {
+	if maxOps <= 0 {
+		maxOps = DefaultBatchMaxOperations
+	}
+	if maxBytes <= 0 {
+		maxBytes = DefaultBatchMaxBytes
+	}
+
+	// The body is {"operations":[op,op,...]}.
+	const overhead = len(`{"operations":[]}`)
+
+	var chunks [][]BatchOperation
+	start, size := 0, overhead
+	for i, op := range ops {
+		data, err := json.Marshal(op)
+		if err != nil {
+			return nil, err
+		}
+		opSize := len(data)
+		if overhead+opSize > maxBytes {
+			return nil, fmt.Errorf("gochimp3: batch operation %d is %d bytes, over the limit of %d", i, opSize, maxBytes)
+		}
+
+		if i > start && (i-start == maxOps || size+1+opSize > maxBytes) {
+			chunks = append(chunks, ops[start:i])
+			start, size = i, overhead
+		}
+		if i > start {
+			size++
+		}
+		size += opSize
+	}
+	if start < len(ops) {
+		chunks = append(chunks, ops[start:])
+	}
+
+	return chunks, nil
+}
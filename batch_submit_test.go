@@ -0,0 +1,103 @@
+package gochimp3
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestChunkOperations(t *testing.T) {
+	ops := make([]BatchOperation, 5)
+	for i := range ops {
+		ops[i] = BatchOperation{Method: "GET", Path: fmt.Sprintf("/lists/l%d", i)}
+	}
+
+	chunks, err := chunkOperations(ops, 2, 0)
+	fatalIf(t, err)
+	assert.Equal(t, [][]BatchOperation{ops[0:2], ops[2:4], ops[4:5]}, chunks)
+
+	// Every operation marshals to the same size, so allow three of them.
+	one, _ := json.Marshal(ops[0])
+	limit := len(`{"operations":[]}`) + 3*len(one) + 2
+	chunks, err = chunkOperations(ops, 0, limit)
+	fatalIf(t, err)
+	assert.Equal(t, [][]BatchOperation{ops[0:3], ops[3:5]}, chunks)
+
+	for _, chunk := range chunks {
+		data, _ := json.Marshal(BatchOperationCreationRequest{Operations: chunk})
+		assert.LessOrEqual(t, len(data), limit)
+	}
+
+	_, err = chunkOperations(ops, 0, len(one))
+	assert.Error(t, err)
+}
+
+func TestSubmitOperations(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		calls   []string
+		created []string
+	)
+
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls = append(calls, r.Method+" "+r.URL.Path)
+
+		switch {
+		case r.Method == "POST" && r.URL.Path == "/batches":
+			var req BatchOperationCreationRequest
+			fatalIf(t, json.NewDecoder(r.Body).Decode(&req))
+			id := fmt.Sprintf("b%d", len(created))
+			created = append(created, req.Operations[0].OperationID+","+req.Operations[len(req.Operations)-1].OperationID)
+			fmt.Fprintf(w, `{"id":%q,"status":"pending","total_operations":%d}`, id, len(req.Operations))
+		case r.Method == "GET" && strings.HasPrefix(r.URL.Path, "/batches/"):
+			id := strings.TrimPrefix(r.URL.Path, "/batches/")
+			fmt.Fprintf(w, `{"id":%q,"status":"finished","response_body_url":%q}`, id, srv.URL+"/results/"+id)
+		case strings.HasPrefix(r.URL.Path, "/results/"):
+			var n int
+			fmt.Sscanf(strings.TrimPrefix(r.URL.Path, "/results/"), "b%d", &n)
+			ids := strings.Split(created[n], ",")
+			var results []map[string]interface{}
+			for _, id := range ids {
+				results = append(results, map[string]interface{}{"status_code": 200, "operation_id": id, "response": "{}"})
+			}
+			w.Write(batchArchive(t, results))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	batch := NewBatchBuilder()
+	for i := 0; i < 4; i++ {
+		batch.DeleteMember("l1", fmt.Sprintf("m%d", i))
+	}
+	req, err := batch.Request()
+	fatalIf(t, err)
+
+	wait := &WaitOptions{MinInterval: time.Millisecond}
+	sub, err := api.SubmitOperations(t.Context(), req.Operations, &SubmitOptions{MaxOperations: 2, MaxInFlight: 1, Wait: wait})
+	fatalIf(t, err)
+	assert.Equal(t, []string{"b0", "b1"}, sub.IDs())
+	assert.Equal(t, []string{"POST /batches", "GET /batches/b0", "POST /batches"}, calls)
+
+	var ids []string
+	fatalIf(t, sub.Results(t.Context(), wait, func(r *BatchOperationResult) error {
+		ids = append(ids, r.OperationID)
+		return nil
+	}))
+	assert.Equal(t, []string{req.Operations[0].OperationID, req.Operations[1].OperationID, req.Operations[2].OperationID, req.Operations[3].OperationID}, ids)
+	assert.Equal(t, "finished", sub.Batches[1].Status)
+}
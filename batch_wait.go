@@ -0,0 +1,94 @@
+package gochimp3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+)
+
+// ErrBatchStuck is returned by WaitForBatch when a batch makes no progress
+// for longer than WaitOptions.StuckTimeout.
+var ErrBatchStuck = errors.New("gochimp3: batch is not progressing")
+
+// WaitOptions controls how WaitForBatch polls. A nil *WaitOptions uses the
+// defaults.
+type WaitOptions struct {
+	// MinInterval is the delay before the second poll. Every further poll
+	// doubles it, up to MaxInterval. Half of each delay is randomised.
+	// They default to 1s and 30s.
+	MinInterval time.Duration
+	MaxInterval time.Duration
+
+	// StuckTimeout makes WaitForBatch give up when neither the status nor
+	// the number of finished operations changed for that long. Zero waits
+	// until ctx is done.
+	StuckTimeout time.Duration
+
+	// Progress is called with the batch after every poll.
+	Progress func(*BatchOperationResponse)
+}
+
+// WaitForBatch polls the batch until its status is "finished" and returns
+// it:
+//
+//	batch, err := client.WaitForBatch(ctx, resp.ID, &gochimp3.WaitOptions{
+//		StuckTimeout: 10 * time.Minute,
+//		Progress: func(b *gochimp3.BatchOperationResponse) {
+//			log.Printf("%d/%d done, %d errors", b.FinishedOperations, b.TotalOperations, b.ErroredOperations)
+//		},
+//	})
+//
+// When the batch is stuck the error matches ErrBatchStuck, and the last
+// state of the batch is returned with it.
+//
+// The completion of the batch is reported to Metrics when WaitForBatch
+// returns it finished, on the first poll included, rather than by
+// GetBatchOperation, so that fetching it again doesn't count it twice.
+func (api *API) WaitForBatch(ctx context.Context, id string, opts *WaitOptions) (*BatchOperationResponse, error) {
+	return api.waitForBatch(ctx, id, opts, nil)
+}
+
+// waitForBatch is WaitForBatch for a batch last seen as last, which may be
+// nil.
+func (api *API) waitForBatch(ctx context.Context, id string, opts *WaitOptions, last *BatchOperationResponse) (*BatchOperationResponse, error) {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+	backoff := RetryPolicy{MinBackoff: opts.MinInterval, MaxBackoff: opts.MaxInterval}
+	if backoff.MinBackoff <= 0 {
+		backoff.MinBackoff = time.Second
+	}
+	if backoff.MaxBackoff <= 0 {
+		backoff.MaxBackoff = 30 * time.Second
+	}
+
+	changed := time.Now()
+
+	for poll := 1; ; poll++ {
+		batch, err := api.GetBatchOperation(ctx, id, nil)
+		if err != nil {
+			return last, err
+		}
+
+		if opts.Progress != nil {
+			opts.Progress(batch)
+		}
+		if batch.Status == "finished" {
+			api.observeBatch(batch, false)
+			return batch, nil
+		}
+
+		if last == nil || batch.Status != last.Status || batch.FinishedOperations != last.FinishedOperations {
+			changed = time.Now()
+		} else if opts.StuckTimeout > 0 && time.Since(changed) >= opts.StuckTimeout {
+			return batch, fmt.Errorf("%w: %s has been %s with %d of %d operations finished for %s",
+				ErrBatchStuck, id, batch.Status, batch.FinishedOperations, batch.TotalOperations, opts.StuckTimeout)
+		}
+		last = batch
+
+		if err := sleep(ctx, backoff.backoff(poll)); err != nil {
+			return last, err
+		}
+	}
+}
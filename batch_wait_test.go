@@ -0,0 +1,66 @@
+package gochimp3
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func batchAPI(t *testing.T, states ...string) *API {
+	polls := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/batches/b1", r.URL.Path)
+		state := states[min(polls, len(states)-1)]
+		polls++
+		fmt.Fprintf(w, `{"id":"b1",%s}`, state)
+	}))
+	t.Cleanup(srv.Close)
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+	return api
+}
+
+func TestWaitForBatch(t *testing.T) {
+	api := batchAPI(t,
+		`"status":"pending","total_operations":3`,
+		`"status":"started","total_operations":3,"finished_operations":1`,
+		`"status":"started","total_operations":3,"finished_operations":2,"errored_operations":1`,
+		`"status":"finished","total_operations":3,"finished_operations":3,"errored_operations":1`,
+	)
+
+	var finished []int
+	batch, err := api.WaitForBatch(t.Context(), "b1", &WaitOptions{
+		MinInterval:  time.Millisecond,
+		MaxInterval:  2 * time.Millisecond,
+		StuckTimeout: time.Minute,
+		Progress: func(b *BatchOperationResponse) {
+			finished = append(finished, b.FinishedOperations)
+		},
+	})
+	fatalIf(t, err)
+	assert.Equal(t, []int{0, 1, 2, 3}, finished)
+	assert.Equal(t, "finished", batch.Status)
+	assert.Equal(t, 1, batch.ErroredOperations)
+}
+
+func TestWaitForBatchStuck(t *testing.T) {
+	api := batchAPI(t,
+		`"status":"pending","total_operations":3`,
+		`"status":"started","total_operations":3,"finished_operations":1`,
+	)
+
+	batch, err := api.WaitForBatch(t.Context(), "b1", &WaitOptions{
+		MinInterval:  time.Millisecond,
+		MaxInterval:  2 * time.Millisecond,
+		StuckTimeout: 20 * time.Millisecond,
+	})
+	assert.ErrorIs(t, err, ErrBatchStuck)
+	if assert.NotNil(t, batch) {
+		assert.Equal(t, 1, batch.FinishedOperations)
+	}
+}
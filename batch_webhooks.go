@@ -0,0 +1,164 @@
+package gochimp3
+
+import (
+	"context"
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+)
+
+const (
+	batch_webhooks_path       = "/batch-webhooks"
+	single_batch_webhook_path = batch_webhooks_path + "/%s"
+)
+
+type ListOfBatchWebhooks struct {
+	baseList
+	Webhooks []BatchWebhook `json:"webhooks"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfBatchWebhooks) UnmarshalJSON(data []byte) error {
+	type alias ListOfBatchWebhooks
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
+}
+
+type BatchWebhookRequest struct {
+	URL string `json:"url"`
+
+	// Enabled turns the webhook on or off. Nil leaves it unchanged, and new
+	// webhooks enabled.
+	Enabled *bool `json:"enabled,omitempty"`
+}
+
+// Validate checks req for creating a batch webhook. Updates only check the
+// fields they set.
+func (req BatchWebhookRequest) Validate() error {
+	var errs fieldErrors
+	errs.absoluteURL("url", req.URL)
+	return errs.err()
+}
+
+func (req BatchWebhookRequest) validatePartial() error {
+	var errs fieldErrors
+	if req.URL != "" {
+		errs.absoluteURL("url", req.URL)
+	}
+	return errs.err()
+}
+
+type BatchWebhook struct {
+	withLinks
+
+	ID      string `json:"id"`
+	URL     string `json:"url"`
+	Enabled bool   `json:"enabled"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *BatchWebhook) UnmarshalJSON(data []byte) error {
+	type alias BatchWebhook
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
+}
+
+func (api *API) GetBatchWebhooks(ctx context.Context, params *ExtendedQueryParams) (*ListOfBatchWebhooks, error) {
+	response := new(ListOfBatchWebhooks)
+
+	return response, api.do(ctx, newOperation("GetBatchWebhooks", "GET", batch_webhooks_path), params, nil, response)
+}
+
+func (api *API) GetBatchWebhook(ctx context.Context, id string, params *BasicQueryParams) (*BatchWebhook, error) {
+	op := newOperation("GetBatchWebhook", "GET", single_batch_webhook_path, id)
+	response := new(BatchWebhook)
+
+	return response, api.do(ctx, op, params, nil, response)
+}
+
+func (api *API) CreateBatchWebhook(ctx context.Context, body *BatchWebhookRequest) (*BatchWebhook, error) {
+	response := new(BatchWebhook)
+
+	return response, api.do(ctx, newOperation("CreateBatchWebhook", "POST", batch_webhooks_path), nil, body, response)
+}
+
+func (api *API) UpdateBatchWebhook(ctx context.Context, id string, body *BatchWebhookRequest) (*BatchWebhook, error) {
+	op := newOperation("UpdateBatchWebhook", "PATCH", single_batch_webhook_path, id)
+	response := new(BatchWebhook)
+
+	return response, api.do(ctx, op, nil, body, response)
+}
+
+func (api *API) DeleteBatchWebhook(ctx context.Context, id string) (bool, error) {
+	op := newOperation("DeleteBatchWebhook", "DELETE", single_batch_webhook_path, id)
+	return api.doOk(ctx, op)
+}
+
+// BatchWebhookHandler returns the handler of a batch webhook's URL, which
+// calls fn with every batch Mailchimp reports as completed. Anyone can post
+// to that URL, so the URL must carry secret in its query, which the handler
+// checks, and the callback is only taken as a notification: the batch is
+// fetched again with GetBatchOperation and fn is only called once it's
+// finished.
+//
+//	client.CreateBatchWebhook(ctx, &gochimp3.BatchWebhookRequest{
+//		URL: "https://example.com/mailchimp/batches?secret=" + url.QueryEscape(secret),
+//	})
+//	http.Handle("/mailchimp/batches", client.BatchWebhookHandler(secret,
+//		func(ctx context.Context, batch *gochimp3.BatchOperationResponse) error {
+//			return client.GetBatchResults(ctx, batch, process)
+//		}))
+//
+// Requests without the secret are answered with a 403, and an error from
+// fetching the batch or from fn with a 500. GET requests, which Mailchimp
+// sends to check the URL, are answered with a 200.
+func (api *API) BatchWebhookHandler(secret string, fn func(ctx context.Context, batch *BatchOperationResponse) error) http.Handler {
+	if secret == "" {
+		panic("gochimp3: BatchWebhookHandler needs a secret")
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		given := r.URL.Query().Get("secret")
+		if subtle.ConstantTimeCompare([]byte(given), []byte(secret)) != 1 {
+			http.Error(w, http.StatusText(http.StatusForbidden), http.StatusForbidden)
+			return
+		}
+
+		switch r.Method {
+		case http.MethodGet, http.MethodHead:
+			return
+		case http.MethodPost:
+		default:
+			w.Header().Set("Allow", "GET, HEAD, POST")
+			http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err := r.ParseForm(); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if typ := r.PostForm.Get("type"); typ != "" && typ != "batch_operation_completed" {
+			return
+		}
+
+		id := r.PostForm.Get("data[id]")
+		if id == "" {
+			http.Error(w, "missing data[id]", http.StatusBadRequest)
+			return
+		}
+
+		batch, err := api.GetBatchOperation(r.Context(), id, nil)
+		if err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+			return
+		}
+		if batch.Status != "finished" {
+			return
+		}
+
+		if err := fn(r.Context(), batch); err != nil {
+			http.Error(w, http.StatusText(http.StatusInternalServerError), http.StatusInternalServerError)
+		}
+	})
+}
@@ -0,0 +1,136 @@
+package gochimp3
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBatchWebhooks(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /batch-webhooks":
+			w.Write([]byte(`{"webhooks":[{"id":"w1","url":"https://example.com/hook","enabled":true}],"total_items":1}`))
+		case "GET /batch-webhooks/w1":
+			w.Write([]byte(`{"id":"w1","url":"https://example.com/hook","enabled":true}`))
+		case "POST /batch-webhooks":
+			assert.JSONEq(t, `{"url":"https://example.com/hook"}`, string(data))
+			w.Write([]byte(`{"id":"w1","url":"https://example.com/hook","enabled":true}`))
+		case "PATCH /batch-webhooks/w1":
+			assert.JSONEq(t, `{"url":"https://example.com/hook","enabled":false}`, string(data))
+			w.Write([]byte(`{"id":"w1","url":"https://example.com/hook","enabled":false}`))
+		case "DELETE /batch-webhooks/w1":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	hooks, err := api.GetBatchWebhooks(t.Context(), nil)
+	fatalIf(t, err)
+	if assert.Len(t, hooks.Webhooks, 1) {
+		assert.Equal(t, "w1", hooks.Webhooks[0].ID)
+	}
+
+	hook, err := api.GetBatchWebhook(t.Context(), "w1", nil)
+	fatalIf(t, err)
+	assert.True(t, hook.Enabled)
+
+	hook, err = api.CreateBatchWebhook(t.Context(), &BatchWebhookRequest{URL: "https://example.com/hook"})
+	fatalIf(t, err)
+	assert.Equal(t, "w1", hook.ID)
+
+	disabled := false
+	hook, err = api.UpdateBatchWebhook(t.Context(), "w1", &BatchWebhookRequest{URL: "https://example.com/hook", Enabled: &disabled})
+	fatalIf(t, err)
+	assert.False(t, hook.Enabled)
+
+	ok, err := api.DeleteBatchWebhook(t.Context(), "w1")
+	fatalIf(t, err)
+	assert.True(t, ok)
+
+	_, err = api.CreateBatchWebhook(t.Context(), &BatchWebhookRequest{URL: "/hook"})
+	assert.ErrorIs(t, err, ErrInvalidResource)
+}
+
+func TestBatchWebhookHandler(t *testing.T) {
+	fetches := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fetches++
+		switch r.URL.Path {
+		case "/batches/b1":
+			w.Write([]byte(`{"id":"b1","status":"finished","total_operations":3,"finished_operations":3,"errored_operations":1,"response_body_url":"https://results.example.com/b1.tar.gz"}`))
+		case "/batches/b2":
+			w.Write([]byte(`{"id":"b2","status":"started"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"title":"Resource Not Found","status":404}`))
+		}
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	var got *BatchOperationResponse
+	fail := false
+	h := api.BatchWebhookHandler("s3cret", func(ctx context.Context, batch *BatchOperationResponse) error {
+		got = batch
+		if fail {
+			return errors.New("boom")
+		}
+		return nil
+	})
+
+	post := func(target, id string) int {
+		form := url.Values{
+			"type":                    {"batch_operation_completed"},
+			"data[id]":                {id},
+			"data[status]":            {"finished"},
+			"data[response_body_url]": {"http://169.254.169.254/latest/meta-data"},
+		}
+		r := httptest.NewRequest("POST", target, strings.NewReader(form.Encode()))
+		r.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	w := httptest.NewRecorder()
+	h.ServeHTTP(w, httptest.NewRequest("GET", "/hook?secret=s3cret", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	// The forged response_body_url is ignored in favour of the batch's own.
+	assert.Equal(t, http.StatusOK, post("/hook?secret=s3cret", "b1"))
+	if assert.NotNil(t, got) {
+		assert.Equal(t, "b1", got.ID)
+		assert.Equal(t, 3, got.TotalOperations)
+		assert.Equal(t, 1, got.ErroredOperations)
+		assert.Equal(t, "https://results.example.com/b1.tar.gz", got.ResponseBodyUrl)
+	}
+
+	got = nil
+	assert.Equal(t, http.StatusForbidden, post("/hook", "b1"))
+	assert.Equal(t, http.StatusForbidden, post("/hook?secret=guess", "b1"))
+	assert.Equal(t, 1, fetches)
+
+	assert.Equal(t, http.StatusOK, post("/hook?secret=s3cret", "b2"))
+	assert.Equal(t, http.StatusInternalServerError, post("/hook?secret=s3cret", "nope"))
+	assert.Nil(t, got)
+
+	fail = true
+	assert.Equal(t, http.StatusInternalServerError, post("/hook?secret=s3cret", "b1"))
+}
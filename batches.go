@@ -2,8 +2,9 @@ package gochimp3
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 	"net/url"
+	"strconv"
 )
 
 const (
@@ -14,13 +15,13 @@ const (
 func (api *API) GetBatchOperations(ctx context.Context, params *ListQueryParams) (*ListOfBatchOperations, error) {
 	response := new(ListOfBatchOperations)
 
-	err := api.request(ctx, "GET", batches_path, params, nil, response)
+	err := api.do(ctx, newOperation("GetBatchOperations", "GET", batches_path), params, nil, response)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, l := range response.BatchOperations {
-		l.api = api
+	for i := range response.BatchOperations {
+		response.BatchOperations[i].api = api
 	}
 
 	return response, nil
@@ -29,41 +30,81 @@ func (api *API) GetBatchOperations(ctx context.Context, params *ListQueryParams)
 type ListOfBatchOperations struct {
 	baseList
 	BatchOperations []BatchOperationResponse `json:"batches"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfBatchOperations) UnmarshalJSON(data []byte) error {
+	type alias ListOfBatchOperations
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (api *API) GetBatchOperation(ctx context.Context, id string, params *BasicQueryParams) (*BatchOperationResponse, error) {
-	endpoint := fmt.Sprintf(single_batch_path, id)
+	op := newOperation("GetBatchOperation", "GET", single_batch_path, id)
 	response := new(BatchOperationResponse)
 	response.api = api
 
-	return response, api.request(ctx, "GET", endpoint, params, nil, response)
+	err := api.do(ctx, op, params, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
 }
 
 func (api *API) CreateBatchOperation(ctx context.Context, body *BatchOperationCreationRequest) (*BatchOperationResponse, error) {
 	response := new(BatchOperationResponse)
 	response.api = api
-	return response, api.request(ctx, "POST", batches_path, nil, body, response)
+
+	err := api.do(ctx, newOperation("CreateBatchOperation", "POST", batches_path), nil, body, response)
+	if err != nil {
+		return nil, err
+	}
+
+	api.observeBatch(response, true)
+
+	return response, nil
 }
 
 type BatchOperationCreationRequest struct {
 	Operations []BatchOperation `json:"operations"`
 }
 
+func (req BatchOperationCreationRequest) Validate() error {
+	var errs fieldErrors
+	if len(req.Operations) == 0 {
+		errs.add("operations", "is required")
+	}
+	for i, op := range req.Operations {
+		field := "operations[" + strconv.Itoa(i) + "]"
+		errs.oneOf(field+".method", op.Method, "GET", "POST", "PUT", "PATCH", "DELETE")
+		errs.required(field+".path", op.Path)
+	}
+	return errs.err()
+}
+
 type BatchOperationResponse struct {
-	Links []Link `json:"_links,omitempty"`
+	withLinks
 
 	ID                 string `json:"id"`
 	Status             string `json:"status"`
 	TotalOperations    int    `json:"total_operations"`
 	FinishedOperations int    `json:"finished_operations"`
 	ErroredOperations  int    `json:"errored_operations"`
-	SubmittedAt        string `json:"submitted_at,omitempty"`
-	CompletedAt        string `json:"completed_at,omitempty"`
+	SubmittedAt        Time   `json:"submitted_at,omitzero"`
+	CompletedAt        Time   `json:"completed_at,omitzero"`
 	ResponseBodyUrl    string `json:"response_body_url"`
 
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (v *BatchOperationResponse) UnmarshalJSON(data []byte) error {
+	type alias BatchOperationResponse
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
+}
+
 type BatchOperation struct {
 	Method      string     `json:"method"`
 	Path        string     `json:"path"`
@@ -1,6 +1,9 @@
 package gochimp3
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 const (
 	campaign_folders_path = "/campaign-folders"
@@ -14,6 +17,13 @@ type CampaignFolderQueryParams struct {
 type ListOfCampaignFolders struct {
 	baseList
 	Folders []CampaignFolder `json:"folders"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfCampaignFolders) UnmarshalJSON(data []byte) error {
+	type alias ListOfCampaignFolders
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type CampaignFolder struct {
@@ -23,23 +33,36 @@ type CampaignFolder struct {
 	ID    string `json:"id"`
 	Count uint   `json:"count"`
 
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (v *CampaignFolder) UnmarshalJSON(data []byte) error {
+	type alias CampaignFolder
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
+}
+
 type CampaignFolderCreationRequest struct {
 	Name string `json:"name"`
 }
 
+func (req CampaignFolderCreationRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("name", req.Name)
+	return errs.err()
+}
+
 func (api *API) GetCampaignFolders(ctx context.Context, params *CampaignFolderQueryParams) (*ListOfCampaignFolders, error) {
 	response := new(ListOfCampaignFolders)
 
-	err := api.request(ctx, "GET", campaign_folders_path, params, nil, response)
+	err := api.do(ctx, newOperation("GetCampaignFolders", "GET", campaign_folders_path), params, nil, response)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, l := range response.Folders {
-		l.api = api
+	for i := range response.Folders {
+		response.Folders[i].api = api
 	}
 
 	return response, nil
@@ -48,5 +71,5 @@ func (api *API) GetCampaignFolders(ctx context.Context, params *CampaignFolderQu
 func (api *API) CreateCampaignFolder(ctx context.Context, body *CampaignFolderCreationRequest) (*CampaignFolder, error) {
 	response := new(CampaignFolder)
 	response.api = api
-	return response, api.request(ctx, "POST", campaign_folders_path, nil, body, response)
+	return response, api.do(ctx, newOperation("CreateCampaignFolder", "POST", campaign_folders_path), nil, body, response)
 }
@@ -2,8 +2,8 @@ package gochimp3
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
 	"time"
 )
 
@@ -37,36 +37,38 @@ const (
 type CampaignQueryParams struct {
 	ExtendedQueryParams
 
-	Type             string
-	Status           string
-	BeforeSendTime   string
-	SinceSendTime    string
-	BeforeCreateTime string
-	SinceCreateTime  string
-	ListId           string
-	FolderId         string
-	SortField        string
-	SortDir          string
+	Type             string    `url:"type"`
+	BeforeSendTime   time.Time `url:"before_send_time"`
+	SinceSendTime    time.Time `url:"since_send_time"`
+	BeforeCreateTime time.Time `url:"before_create_time"`
+	SinceCreateTime  time.Time `url:"since_create_time"`
+	ListId           string    `url:"list_id"`
+	FolderId         string    `url:"folder_id"`
+
+	// Status, SortField and SortDir are sent instead of the fields of
+	// BasicQueryParams when set.
+	//
+	// Deprecated: set Status, SortField and SortDirection of
+	// BasicQueryParams.
+	Status    string `url:"status"`
+	SortField string `url:"sort_field"`
+	SortDir   string `url:"sort_dir"`
 }
 
 func (q CampaignQueryParams) Params() map[string]string {
-	m := q.ExtendedQueryParams.Params()
-	m["type"] = q.Type
-	m["status"] = q.Status
-	m["before_send_time"] = q.BeforeSendTime
-	m["since_send_time"] = q.SinceSendTime
-	m["before_create_time"] = q.BeforeCreateTime
-	m["since_create_time"] = q.SinceCreateTime
-	m["list_id"] = q.ListId
-	m["folder_id"] = q.FolderId
-	m["sort_field"] = q.SortField
-	m["sort_dir"] = q.SortDir
-	return m
+	return encodeQuery(&q)
 }
 
 type ListOfCampaigns struct {
 	baseList
 	Campaigns []*CampaignResponse `json:"campaigns"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfCampaigns) UnmarshalJSON(data []byte) error {
+	type alias ListOfCampaigns
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type CampaignCreationRecipients struct {
@@ -130,7 +132,7 @@ type CampaignCreationSettings struct {
 }
 
 type CampaignCreationRequest struct {
-	Type       string                     `json:"type"` // must be one of the CAMPAIGN_TYPE_* consts
+	Type       string                     `json:"type,omitempty"` // must be one of the CAMPAIGN_TYPE_* consts
 	Recipients CampaignCreationRecipients `json:"recipients"`
 	Settings   CampaignCreationSettings   `json:"settings"`
 	// variate_settings not implemented
@@ -139,11 +141,37 @@ type CampaignCreationRequest struct {
 	// social_card not implemented
 }
 
+// Validate checks req for creating a campaign. Updates only check the fields
+// they set.
+func (req CampaignCreationRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("type", req.Type)
+	return errs.merge(req.validatePartial())
+}
+
+func (req CampaignCreationRequest) validatePartial() error {
+	var errs fieldErrors
+	if req.Type != "" {
+		errs.oneOf("type", req.Type, CAMPAIGN_TYPE_REGULAR, CAMPAIGN_TYPE_PLAINTEXT, CAMPAIGN_TYPE_ABSPLIT, CAMPAIGN_TYPE_RSS, CAMPAIGN_TYPE_VARIATE)
+	}
+	if match := req.Recipients.SegmentOptions.Match; match != "" {
+		errs.oneOf("recipients.segment_opts.match", match, CONDITION_MATCH_ANY, CONDITION_MATCH_ALL)
+	}
+	return errs.err()
+}
+
 type CampaignResponseRecipients struct {
 	ListId         string `json:"list_id"`
 	ListName       string `json:"list_name"`
 	SegmentText    string `json:"segment_text"`
 	RecipientCount int64  `json:"recipient_count"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *CampaignResponseRecipients) UnmarshalJSON(data []byte) error {
+	type alias CampaignResponseRecipients
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type CampaignResponseSettings struct {
@@ -163,6 +191,13 @@ type CampaignResponseSettings struct {
 	Timewarp        bool   `json:"timewarp"`
 	TemplateId      uint   `json:"template_id"`
 	DragAndDrop     bool   `json:"drag_and_drop"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *CampaignResponseSettings) UnmarshalJSON(data []byte) error {
+	type alias CampaignResponseSettings
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type CampaignTracking struct {
@@ -173,12 +208,26 @@ type CampaignTracking struct {
 	Ecomm360        bool   `json:"ecomm360"`
 	GoogleAnalytics string `json:"google_analytics"`
 	Clicktale       string `json:"clicktale"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *CampaignTracking) UnmarshalJSON(data []byte) error {
+	type alias CampaignTracking
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type CampaignEcommerce struct {
 	TotalOrders  int64 `json:"total_orders"`
 	TotalSpent   int64 `json:"total_spent"`
 	TotalRevenue int64 `json:"total_revenue"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *CampaignEcommerce) UnmarshalJSON(data []byte) error {
+	type alias CampaignEcommerce
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type CampaignReportSummary struct {
@@ -189,6 +238,13 @@ type CampaignReportSummary struct {
 	SubscriberClicks int64             `json:"subscriber_clicks"`
 	ClickRate        float64           `json:"click_rate"`
 	Ecommerce        CampaignEcommerce `json:"ecommerce"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *CampaignReportSummary) UnmarshalJSON(data []byte) error {
+	type alias CampaignReportSummary
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type CampaignDeliveryStatus struct {
@@ -198,6 +254,13 @@ type CampaignDeliveryStatus struct {
 	Status         string `json:"status"`
 	EmailsSent     int64  `json:"emails_sent"`
 	EmailsCanceled int64  `json:"emails_canceled"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *CampaignDeliveryStatus) UnmarshalJSON(data []byte) error {
+	type alias CampaignDeliveryStatus
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type CampaignResponse struct {
@@ -206,12 +269,12 @@ type CampaignResponse struct {
 	ID                string                     `json:"id"`
 	WebID             int64                      `json:"web_id"`
 	Type              string                     `json:"type"`
-	CreateTime        string                     `json:"create_time"`
+	CreateTime        Time                       `json:"create_time"`
 	ArchiveUrl        string                     `json:"archive_url"`
 	LongArchiveUrl    string                     `json:"long_archive_url"`
 	Status            string                     `json:"status"`
 	EmailsSent        int64                      `json:"emails_sent"`
-	SendTime          string                     `json:"send_time"`
+	SendTime          Time                       `json:"send_time"`
 	ContentType       string                     `json:"content_type"`
 	NeedsBlockRefresh bool                       `json:"needs_block_refresh"`
 	Recipients        CampaignResponseRecipients `json:"recipients"`
@@ -220,9 +283,16 @@ type CampaignResponse struct {
 	ReportSummary     CampaignReportSummary      `json:"report_summary"`
 	DeliveryStatus    CampaignDeliveryStatus     `json:"delivery_status"`
 
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (campaign *CampaignResponse) UnmarshalJSON(data []byte) error {
+	type alias CampaignResponse
+	return unmarshalWithExtra(data, (*alias)(campaign), &campaign.Extra)
+}
+
 func (campaign CampaignResponse) CanMakeRequest() error {
 	if campaign.ID == "" {
 		return errors.New("No ID provided on campaign")
@@ -234,7 +304,7 @@ func (campaign CampaignResponse) CanMakeRequest() error {
 func (api *API) GetCampaigns(ctx context.Context, params *CampaignQueryParams) (*ListOfCampaigns, error) {
 	response := new(ListOfCampaigns)
 
-	err := api.request(ctx, "GET", campaigns_path, params, nil, response)
+	err := api.do(ctx, newOperation("GetCampaigns", "GET", campaigns_path), params, nil, response)
 	if err != nil {
 		return nil, err
 	}
@@ -247,32 +317,32 @@ func (api *API) GetCampaigns(ctx context.Context, params *CampaignQueryParams) (
 }
 
 func (api *API) GetCampaign(ctx context.Context, id string, params *BasicQueryParams) (*CampaignResponse, error) {
-	endpoint := fmt.Sprintf(single_campaign_path, id)
+	op := newOperation("GetCampaign", "GET", single_campaign_path, id)
 
 	response := new(CampaignResponse)
 	response.api = api
 
-	return response, api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, api.do(ctx, op, params, nil, response)
 }
 
 func (api *API) CreateCampaign(ctx context.Context, body *CampaignCreationRequest) (*CampaignResponse, error) {
 	response := new(CampaignResponse)
 	response.api = api
-	return response, api.request(ctx, "POST", campaigns_path, nil, body, response)
+	return response, api.do(ctx, newOperation("CreateCampaign", "POST", campaigns_path), nil, body, response)
 }
 
 func (api *API) UpdateCampaign(ctx context.Context, id string, body *CampaignCreationRequest) (*CampaignResponse, error) {
-	endpoint := fmt.Sprintf(single_campaign_path, id)
+	op := newOperation("UpdateCampaign", "PATCH", single_campaign_path, id)
 
 	response := new(CampaignResponse)
 	response.api = api
 
-	return response, api.request(ctx, "PATCH", endpoint, nil, body, response)
+	return response, api.do(ctx, op, nil, body, response)
 }
 
 func (api *API) DeleteCampaign(ctx context.Context, id string) (bool, error) {
-	endpoint := fmt.Sprintf(single_campaign_path, id)
-	return api.requestOk(ctx, "DELETE", endpoint)
+	op := newOperation("DeleteCampaign", "DELETE", single_campaign_path, id)
+	return api.doOk(ctx, op)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -284,6 +354,15 @@ type TestEmailRequest struct {
 	SendType   string   `json:"send_type"` // one of the CAMPAIGN_SEND_TYPE_* constants
 }
 
+func (req TestEmailRequest) Validate() error {
+	var errs fieldErrors
+	if len(req.TestEmails) == 0 {
+		errs.add("test_emails", "is required")
+	}
+	errs.oneOf("send_type", req.SendType, CAMPAIGN_SEND_TYPE_HTML, CAMPAIGN_SEND_TYPE_PLAINTEXT)
+	return errs.err()
+}
+
 type SendCampaignRequest struct {
 	CampaignId string `json:"campaign_id"`
 }
@@ -293,9 +372,23 @@ type ScheduleCampaignRequest struct {
 	ScheduleTime string `json:"schedule_time"`
 }
 
+func (req ScheduleCampaignRequest) Validate() error {
+	var errs fieldErrors
+	t, err := time.Parse(time.RFC3339, req.ScheduleTime)
+	switch {
+	case req.ScheduleTime == "":
+		errs.add("schedule_time", "is required")
+	case err != nil:
+		errs.add("schedule_time", "is not an ISO 8601 time")
+	case t.Minute()%15 != 0 || t.Second() != 0 || t.Nanosecond() != 0:
+		errs.add("schedule_time", "must be on the quarter-hour")
+	}
+	return errs.err()
+}
+
 func (api *API) SendTestEmail(ctx context.Context, id string, body *TestEmailRequest) (bool, error) {
-	endpoint := fmt.Sprintf(send_test_path, id)
-	err := api.request(ctx, "POST", endpoint, nil, body, nil)
+	op := newOperation("SendTestEmail", "POST", send_test_path, id)
+	err := api.do(ctx, op, nil, body, nil)
 
 	if err != nil {
 		return false, err
@@ -304,8 +397,8 @@ func (api *API) SendTestEmail(ctx context.Context, id string, body *TestEmailReq
 }
 
 func (api *API) SendCampaign(ctx context.Context, id string, body *SendCampaignRequest) (bool, error) {
-	endpoint := fmt.Sprintf(send_path, id)
-	err := api.request(ctx, "POST", endpoint, nil, body, nil)
+	op := newOperation("SendCampaign", "POST", send_path, id)
+	err := api.do(ctx, op, nil, body, nil)
 
 	if err != nil {
 		return false, err
@@ -319,8 +412,8 @@ func (api *API) ScheduleCampaign(ctx context.Context, id string, scheduleTime *t
 		ScheduleTime: scheduleTime.Format(time.RFC3339),
 	}
 
-	endpoint := fmt.Sprintf(schedule_path, id)
-	err := api.request(ctx, "POST", endpoint, nil, body, nil)
+	op := newOperation("ScheduleCampaign", "POST", schedule_path, id)
+	err := api.do(ctx, op, nil, body, nil)
 
 	if err != nil {
 		return false, err
@@ -329,8 +422,8 @@ func (api *API) ScheduleCampaign(ctx context.Context, id string, scheduleTime *t
 }
 
 func (api *API) UnscheduleCampaign(ctx context.Context, id string) (bool, error) {
-	endpoint := fmt.Sprintf(unschedule_path, id)
-	return api.requestOk(ctx, "POST", endpoint)
+	op := newOperation("UnscheduleCampaign", "POST", unschedule_path, id)
+	return api.doOk(ctx, op)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -352,22 +445,29 @@ type CampaignContentUpdateRequest struct {
 type CampaignContentResponse struct {
 	withLinks
 
-	PlainText   string `json:"plain_text"`
-	Html        string `json:"html"`
-	ArchiveHtml string `json:"archive_html"`
-	api         *API
+	PlainText   string                     `json:"plain_text"`
+	Html        string                     `json:"html"`
+	ArchiveHtml string                     `json:"archive_html"`
+	Extra       map[string]json.RawMessage `json:"-"`
+
+	api *API
+}
+
+func (v *CampaignContentResponse) UnmarshalJSON(data []byte) error {
+	type alias CampaignContentResponse
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (api *API) GetCampaignContent(ctx context.Context, id string, params *BasicQueryParams) (*CampaignContentResponse, error) {
-	endpoint := fmt.Sprintf(campaign_content_path, id)
+	op := newOperation("GetCampaignContent", "GET", campaign_content_path, id)
 	response := new(CampaignContentResponse)
 	response.api = api
-	return response, api.request(ctx, "GET", endpoint, nil, params, response)
+	return response, api.do(ctx, op, params, nil, response)
 }
 
 func (api *API) UpdateCampaignContent(ctx context.Context, id string, body *CampaignContentUpdateRequest) (*CampaignContentResponse, error) {
-	endpoint := fmt.Sprintf(campaign_content_path, id)
+	op := newOperation("UpdateCampaignContent", "PUT", campaign_content_path, id)
 	response := new(CampaignContentResponse)
 	response.api = api
-	return response, api.request(ctx, "PUT", endpoint, nil, body, response)
+	return response, api.do(ctx, op, nil, body, response)
 }
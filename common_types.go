@@ -1,22 +1,19 @@
 package gochimp3
 
 import (
+	"encoding/json"
 	"fmt"
-	"strings"
 )
 
 // APIError is what the what the api returns on error
 type APIError struct {
-	Type            string `json:"type,omitempty"`
-	Title           string `json:"title,omitempty"`
-	Status          int    `json:"status,omitempty"`
-	Detail          string `json:"detail,omitempty"`
-	Instance        string `json:"instance,omitempty"`
-	ReferenceNumber string `json:"ref_no,omitempty"`
-	Errors          []struct {
-		Field   string `json:"field"`
-		Message string `json:"message"`
-	} `json:"errors,omitempty"`
+	Type            string       `json:"type,omitempty"`
+	Title           string       `json:"title,omitempty"`
+	Status          int          `json:"status,omitempty"`
+	Detail          string       `json:"detail,omitempty"`
+	Instance        string       `json:"instance,omitempty"`
+	ReferenceNumber string       `json:"ref_no,omitempty"`
+	Errors          []FieldError `json:"errors,omitempty"`
 }
 
 func (err *APIError) String() string {
@@ -41,38 +38,40 @@ type QueryParams interface {
 type ExtendedQueryParams struct {
 	BasicQueryParams
 
-	Count  int
-	Offset int
+	Count  int `url:"count"`
+	Offset int `url:"offset"`
 }
 
 func (q *ExtendedQueryParams) Params() map[string]string {
-	m := q.BasicQueryParams.Params()
-	m["count"] = fmt.Sprintf("%d", q.Count)
-	m["offset"] = fmt.Sprintf("%d", q.Offset)
-	return m
+	return encodeQuery(q)
 }
 
 // BasicQueryParams basic filter queries
 type BasicQueryParams struct {
-	Status        string
-	SortField     string
-	SortDirection string
-	Fields        []string
-	ExcludeFields []string
+	Status        string   `url:"status"`
+	SortField     string   `url:"sort_field"`
+	SortDirection string   `url:"sort_dir"`
+	Fields        []string `url:"fields"`
+	ExcludeFields []string `url:"exclude_fields"`
 }
 
 func (q *BasicQueryParams) Params() map[string]string {
-	return map[string]string{
-		"status":         q.Status,
-		"sort_field":     q.SortField,
-		"sort_dir":       q.SortDirection,
-		"fields":         strings.Join(q.Fields, ","),
-		"exclude_fields": strings.Join(q.ExcludeFields, ","),
-	}
+	return encodeQuery(q)
 }
 
 type withLinks struct {
-	Link []Link `json:"_link"`
+	Links []Link `json:"_links,omitempty"`
+}
+
+// Link returns the link with the given relation, e.g. "parent", or nil.
+func (l withLinks) Link(rel string) *Link {
+	return findLink(l.Links, rel)
+}
+
+// Actions lists what can be done with the resource, that is every link but
+// "self".
+func (l withLinks) Actions() []Link {
+	return actions(l.Links)
 }
 
 type baseList struct {
@@ -80,15 +79,45 @@ type baseList struct {
 	Links      []Link `json:"_links"`
 }
 
-// Link refereneces another object
+// Link returns the link with the given relation, e.g. "parent", or nil.
+func (l baseList) Link(rel string) *Link {
+	return findLink(l.Links, rel)
+}
+
+// Actions lists what can be done with the list, that is every link but
+// "self".
+func (l baseList) Actions() []Link {
+	return actions(l.Links)
+}
+
+// Link refereneces another object. See API.Follow to request it.
 type Link struct {
-	Rel          string `json:"re"`
+	Rel          string `json:"rel"`
 	Href         string `json:"href"`
 	Method       string `json:"method"`
 	TargetSchema string `json:"targetSchema"`
 	Schema       string `json:"schema"`
 }
 
+func findLink(links []Link, rel string) *Link {
+	for i := range links {
+		if links[i].Rel == rel {
+			return &links[i]
+		}
+	}
+	return nil
+}
+
+func actions(links []Link) []Link {
+	var out []Link
+	for _, l := range links {
+		if l.Rel != "self" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
 // Address represents what it says
 type Address struct {
 	Address1     string  `json:"address1"`
@@ -119,9 +148,9 @@ type Customer struct {
 	Address      *Address `json:"address,omitempty"`
 
 	// Response
-	CreatedAt string `json:"created_at,omitempty"`
-	UpdatedAt string `json:"updated_at,omitempty"`
-	Links     []Link `json:"_links,omitempty"`
+	CreatedAt Time `json:"created_at,omitzero"`
+	UpdatedAt Time `json:"updated_at,omitzero"`
+	withLinks
 }
 
 // LineItem defines a mailchimp cart or order line item
@@ -148,4 +177,11 @@ type Contact struct {
 	Zip         string `json:"zip"`
 	Country     string `json:"country"`
 	PhoneNumber string `json:"phone"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *Contact) UnmarshalJSON(data []byte) error {
+	type alias Contact
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
@@ -0,0 +1,73 @@
+package gochimp3
+
+import (
+	"bytes"
+	"compress/gzip"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// Compression configures gzip for requests and responses.
+type Compression struct {
+	// RequestThreshold is the body size, in bytes, from which request bodies
+	// are gzipped. Zero or less leaves requests uncompressed.
+	RequestThreshold int
+
+	// Responses asks for gzipped responses by setting Accept-Encoding
+	// explicitly, which is needed with transports that don't do it
+	// themselves, and decompresses them.
+	Responses bool
+}
+
+// DefaultCompression gzips request bodies of 1KB or more, and responses.
+func DefaultCompression() *Compression {
+	return &Compression{
+		RequestThreshold: 1024,
+		Responses:        true,
+	}
+}
+
+// encode gzips body if it reaches the threshold, returning the body to send
+// and its Content-Encoding.
+func (c *Compression) encode(body []byte) ([]byte, string, error) {
+	if c == nil || c.RequestThreshold <= 0 || len(body) < c.RequestThreshold {
+		return body, "", nil
+	}
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(body); err != nil {
+		return nil, "", err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, "", err
+	}
+
+	return buf.Bytes(), "gzip", nil
+}
+
+func (c *Compression) acceptEncoding(req *http.Request) {
+	if c != nil && c.Responses {
+		req.Header.Set("Accept-Encoding", "gzip")
+	}
+}
+
+// decodeBody wraps the body of resp to decompress it when it was gzipped.
+// The returned closer releases the gzip reader, not the body.
+func decodeBody(resp *http.Response) (io.Reader, io.Closer, error) {
+	encoding := strings.TrimSpace(resp.Header.Get("Content-Encoding"))
+	if !strings.EqualFold(encoding, "gzip") {
+		return resp.Body, io.NopCloser(nil), nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err == io.EOF {
+		// An empty body, as some servers send with 204s.
+		return resp.Body, io.NopCloser(nil), nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return zr, zr, nil
+}
@@ -0,0 +1,109 @@
+package gochimp3
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// gzipServer echoes the JSON body it receives, gzipping the response when
+// the client accepts it.
+func gzipServer(t *testing.T, encodings *[]string) *httptest.Server {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		*encodings = append(*encodings, r.Header.Get("Content-Encoding"))
+
+		var body io.Reader = r.Body
+		if r.Header.Get("Content-Encoding") == "gzip" {
+			zr, err := gzip.NewReader(r.Body)
+			fatalIf(t, err)
+			body = zr
+		}
+		data, err := io.ReadAll(body)
+		fatalIf(t, err)
+
+		if r.Header.Get("Accept-Encoding") != "gzip" {
+			w.Write(data)
+			return
+		}
+
+		w.Header().Set("Content-Encoding", "gzip")
+		zw := gzip.NewWriter(w)
+		zw.Write(data)
+		zw.Close()
+	}))
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestCompressionRoundTrip(t *testing.T) {
+	var encodings []string
+	srv := gzipServer(t, &encodings)
+
+	// A transport that doesn't ask for gzip itself.
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+
+	for _, debug := range []bool{false, true} {
+		encodings = nil
+
+		api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""), WithHTTPClient(client))
+		fatalIf(t, err)
+		api.Compression = DefaultCompression()
+		api.Debug = debug
+
+		html := strings.Repeat("<p>Hello subscriber</p>", 200)
+		body := map[string]string{"html": html}
+		var out map[string]string
+		err = api.request(t.Context(), "PUT", "/content", nil, body, &out)
+		fatalIf(t, err)
+		assert.Equal(t, html, out["html"])
+
+		small := map[string]string{"html": "<p>Hi</p>"}
+		err = api.request(t.Context(), "PUT", "/content", nil, small, &out)
+		fatalIf(t, err)
+		assert.Equal(t, "<p>Hi</p>", out["html"])
+
+		assert.Equal(t, []string{"gzip", ""}, encodings)
+	}
+}
+
+func TestCompressionDisabled(t *testing.T) {
+	var encodings []string
+	srv := gzipServer(t, &encodings)
+	client := &http.Client{Transport: &http.Transport{DisableCompression: true}}
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""), WithHTTPClient(client))
+	fatalIf(t, err)
+
+	body := map[string]string{"html": strings.Repeat("x", 4096)}
+	var out map[string]string
+	err = api.request(t.Context(), "PUT", "/content", nil, body, &out)
+	fatalIf(t, err)
+	assert.Equal(t, body, out)
+	assert.Equal(t, []string{""}, encodings)
+}
+
+func TestCompressionEncode(t *testing.T) {
+	c := &Compression{RequestThreshold: 10}
+
+	data, encoding, err := c.encode([]byte(`{"a":1}`))
+	fatalIf(t, err)
+	assert.Equal(t, "", encoding)
+	assert.Equal(t, `{"a":1}`, string(data))
+
+	payload := []byte(`{"a":"0123456789"}`)
+	data, encoding, err = c.encode(payload)
+	fatalIf(t, err)
+	assert.Equal(t, "gzip", encoding)
+
+	zr, err := gzip.NewReader(strings.NewReader(string(data)))
+	fatalIf(t, err)
+	var v map[string]string
+	fatalIf(t, json.NewDecoder(zr).Decode(&v))
+	assert.Equal(t, "0123456789", v["a"])
+}
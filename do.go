@@ -0,0 +1,85 @@
+package gochimp3
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// Query is a QueryParams made of raw query parameters, for use with Do.
+type Query map[string]string
+
+func (q Query) Params() map[string]string {
+	return q
+}
+
+// JoinPath builds an endpoint path from segments, escaping each of them:
+//
+//	gochimp3.JoinPath("reports", campaignID, "open-details")
+//	// "/reports/<campaignID>/open-details"
+//
+// Empty, "." and ".." segments are kept, and make Do fail with
+// ErrInvalidPathArg.
+func JoinPath(segments ...string) string {
+	var b strings.Builder
+	for _, s := range segments {
+		b.WriteByte('/')
+		b.WriteString(url.PathEscape(s))
+	}
+	return b.String()
+}
+
+// Do calls an endpoint gochimp3 doesn't cover yet, decoding a successful
+// response into a new T. path is relative to the API's endpoint, see
+// JoinPath to build it. The call goes through the same authentication,
+// retries, middleware and error handling as the other methods:
+//
+//	type openDetails struct {
+//		Members []struct {
+//			EmailAddress string `json:"email_address"`
+//		} `json:"members"`
+//	}
+//
+//	details, err := gochimp3.Do[openDetails](ctx, api, "GET",
+//		gochimp3.JoinPath("reports", id, "open-details"), gochimp3.Query{"count": "100"}, nil)
+//
+// Use json.RawMessage as T to get the body undecoded, or struct{} to ignore
+// it. Like the IDs given to other methods, no segment of path may be empty,
+// "." or "..", once unescaped.
+func Do[T any](ctx context.Context, api *API, method, path string, params QueryParams, body interface{}) (*T, error) {
+	if err := checkPath(path); err != nil {
+		return nil, err
+	}
+	response := new(T)
+
+	err := api.do(ctx, newOperation("Do", method, path), params, body, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return response, nil
+}
+
+// checkPath is Operation.checkPathArgs for a path built by the caller.
+func checkPath(path string) error {
+	for _, seg := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		if s, err := url.PathUnescape(seg); err == nil {
+			seg = s
+		}
+		if seg == "" || seg == "." || seg == ".." {
+			return fmt.Errorf("%w: %q in %q", ErrInvalidPathArg, seg, path)
+		}
+	}
+	return nil
+}
+
+// Get is Do with the GET method and no body.
+func Get[T any](ctx context.Context, api *API, path string, params QueryParams) (*T, error) {
+	return Do[T](ctx, api, "GET", path, params, nil)
+}
+
+// Post is Do with the POST method and no query parameters.
+func Post[T any](ctx context.Context, api *API, path string, body interface{}) (*T, error) {
+	return Do[T](ctx, api, "POST", path, nil, body)
+}
@@ -0,0 +1,98 @@
+package gochimp3
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDo(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, pass, _ := r.BasicAuth()
+		assert.Equal(t, "gochimp3", user)
+		assert.Equal(t, "apikey", pass)
+
+		switch r.Method {
+		case "GET":
+			assert.Equal(t, "/reports/a%2Fb%3Fc/open-details", r.URL.EscapedPath())
+			assert.Equal(t, "10", r.URL.Query().Get("count"))
+			w.Write([]byte(`{"campaign_id":"a/b?c","total_items":3}`))
+		case "POST":
+			data, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name":"Spring"}`, string(data))
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"status":400,"title":"Invalid Resource","detail":"bad"}`))
+		}
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	var ops []*Operation
+	api.Middleware = []Middleware{
+		func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) error {
+				ops = append(ops, op)
+				return next(ctx, op)
+			}
+		},
+	}
+
+	type report struct {
+		CampaignID string `json:"campaign_id"`
+		TotalItems int    `json:"total_items"`
+	}
+	r, err := Get[report](t.Context(), api, JoinPath("reports", "a/b?c", "open-details"), Query{"count": "10"})
+	fatalIf(t, err)
+	assert.Equal(t, &report{CampaignID: "a/b?c", TotalItems: 3}, r)
+
+	raw, err := Do[json.RawMessage](t.Context(), api, "GET", JoinPath("reports", "a/b?c", "open-details"), Query{"count": "10"}, nil)
+	fatalIf(t, err)
+	assert.JSONEq(t, `{"campaign_id":"a/b?c","total_items":3}`, string(*raw))
+
+	_, err = Post[struct{}](t.Context(), api, "/ecommerce/stores", map[string]string{"name": "Spring"})
+	assert.ErrorIs(t, err, ErrInvalidResource)
+
+	if assert.Len(t, ops, 3) {
+		assert.Equal(t, "Do", ops[0].Name)
+		assert.Equal(t, "POST", ops[2].Method)
+	}
+}
+
+func TestJoinPath(t *testing.T) {
+	assert.Equal(t, "/file-manager/files", JoinPath("file-manager", "files"))
+	assert.Equal(t, "/lists/..%2F..%2Froot/members/a%20b", JoinPath("lists", "../../root", "members", "a b"))
+
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	for _, path := range []string{
+		JoinPath("lists", ".."),
+		JoinPath("lists", "."),
+		JoinPath("lists", "", "members"),
+		JoinPath("lists", "abc", ""),
+		"/lists/%2E%2E",
+		"/lists//members",
+	} {
+		_, err := Get[struct{}](t.Context(), api, path, nil)
+		assert.ErrorIs(t, err, ErrInvalidPathArg, path)
+	}
+	assert.Equal(t, 0, requests)
+
+	_, err = Get[struct{}](t.Context(), api, JoinPath("lists", "..abc"), nil)
+	fatalIf(t, err)
+	assert.Equal(t, 1, requests)
+}
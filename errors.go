@@ -0,0 +1,118 @@
+package gochimp3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// Sentinel errors matched by *APIError and *HTTPError through errors.Is, e.g.
+//
+//	if errors.Is(err, gochimp3.ErrMemberExists) { ... }
+var (
+	ErrBadRequest       = errors.New("bad request")
+	ErrInvalidResource  = errors.New("invalid resource")
+	ErrMemberExists     = errors.New("member exists")
+	ErrComplianceState  = errors.New("member in compliance state")
+	ErrForgottenEmail   = errors.New("forgotten email not subscribed")
+	ErrUnauthorized     = errors.New("unauthorized")
+	ErrForbidden        = errors.New("forbidden")
+	ErrNotFound         = errors.New("resource not found")
+	ErrMethodNotAllowed = errors.New("method not allowed")
+	ErrRateLimited      = errors.New("too many requests")
+	ErrServer           = errors.New("server error")
+)
+
+// titled maps the sentinels that share a status code with others to the
+// Mailchimp error title identifying them.
+var titled = map[error]string{
+	ErrInvalidResource: "Invalid Resource",
+	ErrMemberExists:    "Member Exists",
+	ErrComplianceState: "Member In Compliance State",
+	ErrForgottenEmail:  "Forgotten Email Not Subscribed",
+}
+
+func matchesSentinel(target error, status int, title string) bool {
+	if t, ok := titled[target]; ok {
+		return strings.EqualFold(t, title)
+	}
+
+	switch target {
+	case ErrBadRequest:
+		return status == http.StatusBadRequest
+	case ErrUnauthorized:
+		return status == http.StatusUnauthorized
+	case ErrForbidden:
+		return status == http.StatusForbidden
+	case ErrNotFound:
+		return status == http.StatusNotFound
+	case ErrMethodNotAllowed:
+		return status == http.StatusMethodNotAllowed
+	case ErrRateLimited:
+		return status == http.StatusTooManyRequests
+	case ErrServer:
+		return status >= 500
+	}
+
+	return false
+}
+
+// FieldError describes a problem with a single field of a request body.
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+func (err FieldError) String() string {
+	if err.Field == "" {
+		return err.Message
+	}
+	return err.Field + ": " + err.Message
+}
+
+// Is reports whether err matches one of the sentinel errors above.
+func (err *APIError) Is(target error) bool {
+	return matchesSentinel(target, err.Status, err.Title)
+}
+
+// HTTPError is returned when an error response isn't a Mailchimp API error,
+// e.g. an HTML page served by a proxy. It keeps everything needed to debug
+// it.
+type HTTPError struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+func (err *HTTPError) Error() string {
+	body := strings.TrimSpace(string(err.Body))
+	if len(body) > 200 {
+		body = body[:200] + "..."
+	}
+	return fmt.Sprintf("%d %s: %s", err.StatusCode, http.StatusText(err.StatusCode), body)
+}
+
+// Is reports whether err matches one of the status based sentinel errors.
+func (err *HTTPError) Is(target error) bool {
+	return matchesSentinel(target, err.StatusCode, "")
+}
+
+func parseAPIError(resp *http.Response, data []byte) error {
+	apiError := new(APIError)
+	err := json.Unmarshal(data, apiError)
+	if err != nil || (apiError.Title == "" && apiError.Type == "" && apiError.Detail == "") {
+		return &HTTPError{
+			StatusCode: resp.StatusCode,
+			Header:     resp.Header,
+			Body:       data,
+		}
+	}
+
+	if apiError.Status == 0 {
+		apiError.Status = resp.StatusCode
+	}
+
+	return apiError
+}
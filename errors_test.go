@@ -0,0 +1,63 @@
+package gochimp3
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestAPIErrorSentinels(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/problem+json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{
+			"type": "https://mailchimp.com/developer/marketing/docs/errors/",
+			"title": "Member Exists",
+			"status": 400,
+			"detail": "a@example.com is already a list member.",
+			"instance": "abc",
+			"errors": [{"field": "email_address", "message": "Duplicate"}]
+		}`))
+	}
+
+	api := testAPI()
+	_, err := api.requestOk(t.Context(), "POST", "/somewhere")
+
+	assert.ErrorIs(t, err, ErrMemberExists)
+	assert.ErrorIs(t, err, ErrBadRequest)
+	assert.NotErrorIs(t, err, ErrNotFound)
+	assert.NotErrorIs(t, err, ErrComplianceState)
+
+	var apiErr *APIError
+	assert.True(t, errors.As(err, &apiErr))
+	assert.Equal(t, []FieldError{{Field: "email_address", Message: "Duplicate"}}, apiErr.Errors)
+	assert.Contains(t, apiErr.Error(), "email_address: Duplicate")
+}
+
+func TestNonJSONErrorKeepsStatus(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "text/html")
+		w.Header().Set("Retry-After", "3")
+		w.WriteHeader(http.StatusTooManyRequests)
+		w.Write([]byte("<html>slow down</html>"))
+	}
+
+	api := testAPI()
+	_, err := api.requestOk(t.Context(), "GET", "/somewhere")
+
+	assert.ErrorIs(t, err, ErrRateLimited)
+
+	var httpErr *HTTPError
+	assert.True(t, errors.As(err, &httpErr))
+	assert.Equal(t, http.StatusTooManyRequests, httpErr.StatusCode)
+	assert.Equal(t, "3", httpErr.Header.Get("Retry-After"))
+	assert.Equal(t, "<html>slow down</html>", string(httpErr.Body))
+}
+
+func TestMissingEndpointIsNotFound(t *testing.T) {
+	api := testAPI()
+	_, err := api.requestOk(t.Context(), "GET", "/nowhere")
+	assert.ErrorIs(t, err, ErrNotFound)
+}
@@ -0,0 +1,167 @@
+// Package gochimp3otel instruments gochimp3 calls with OpenTelemetry spans.
+//
+//	api := gochimp3.New(apiKey, nil)
+//	api.Middleware = append(api.Middleware, gochimp3otel.Middleware())
+package gochimp3otel
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"github.com/avantarte/gochimp3"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// ScopeName is the instrumentation scope of the tracer.
+const ScopeName = "github.com/avantarte/gochimp3/gochimp3otel"
+
+// Mailchimp specific span attributes.
+const (
+	OperationKey  = attribute.Key("mailchimp.operation")
+	ListIDKey     = attribute.Key("mailchimp.list_id")
+	CampaignIDKey = attribute.Key("mailchimp.campaign_id")
+	ErrorTypeKey  = attribute.Key("mailchimp.error_type")
+	RequestIDKey  = attribute.Key("mailchimp.request_id")
+	AttemptsKey   = attribute.Key("mailchimp.attempts")
+)
+
+// HTTP span attributes, following the semantic conventions.
+const (
+	methodKey      = attribute.Key("http.request.method")
+	statusCodeKey  = attribute.Key("http.response.status_code")
+	urlTemplateKey = attribute.Key("url.template")
+)
+
+const defaultSpanName = "gochimp3.request"
+
+type config struct {
+	provider    trace.TracerProvider
+	propagators propagation.TextMapPropagator
+}
+
+// Option configures the Middleware.
+type Option func(*config)
+
+// WithTracerProvider sets the provider spans are created with. Defaults to
+// the global one.
+func WithTracerProvider(tp trace.TracerProvider) Option {
+	return func(c *config) {
+		c.provider = tp
+	}
+}
+
+// WithPropagators sets the propagators used to send the trace context to
+// Mailchimp. Defaults to the global ones.
+func WithPropagators(p propagation.TextMapPropagator) Option {
+	return func(c *config) {
+		c.propagators = p
+	}
+}
+
+// Middleware returns a gochimp3.Middleware creating a client span named
+// after the operation, e.g. "gochimp3.ListAddOrUpdateMember", for every
+// call. Retries happen within the span.
+func Middleware(opts ...Option) gochimp3.Middleware {
+	c := config{}
+	for _, opt := range opts {
+		opt(&c)
+	}
+	if c.provider == nil {
+		c.provider = otel.GetTracerProvider()
+	}
+	if c.propagators == nil {
+		c.propagators = otel.GetTextMapPropagator()
+	}
+
+	tracer := c.provider.Tracer(ScopeName)
+
+	return func(next gochimp3.Handler) gochimp3.Handler {
+		return func(ctx context.Context, op *gochimp3.Operation) error {
+			ctx, span := tracer.Start(ctx, spanName(op),
+				trace.WithSpanKind(trace.SpanKindClient),
+				trace.WithAttributes(startAttributes(op)...),
+			)
+			defer span.End()
+
+			c.propagators.Inject(ctx, propagation.HeaderCarrier(op.Header))
+
+			err := next(ctx, op)
+
+			if op.Info != nil {
+				if op.Info.StatusCode != 0 {
+					span.SetAttributes(statusCodeKey.Int(op.Info.StatusCode))
+				}
+				if op.Info.RequestID != "" {
+					span.SetAttributes(RequestIDKey.String(op.Info.RequestID))
+				}
+				span.SetAttributes(AttemptsKey.Int(op.Info.Attempts))
+			}
+
+			if err != nil {
+				var apiErr *gochimp3.APIError
+				if errors.As(err, &apiErr) && apiErr.Title != "" {
+					span.SetAttributes(ErrorTypeKey.String(apiErr.Title))
+				}
+				span.RecordError(err)
+				span.SetStatus(codes.Error, err.Error())
+			}
+
+			return err
+		}
+	}
+}
+
+func spanName(op *gochimp3.Operation) string {
+	if op.Name == "" {
+		return defaultSpanName
+	}
+	return "gochimp3." + op.Name
+}
+
+func startAttributes(op *gochimp3.Operation) []attribute.KeyValue {
+	attrs := []attribute.KeyValue{
+		methodKey.String(op.Method),
+		urlTemplateKey.String(op.PathTemplate),
+	}
+	if op.Name != "" {
+		attrs = append(attrs, OperationKey.String(op.Name))
+	}
+
+	ids := pathIDs(op)
+	if id := ids["lists"]; id != "" {
+		attrs = append(attrs, ListIDKey.String(id))
+	}
+	if id := ids["campaigns"]; id != "" {
+		attrs = append(attrs, CampaignIDKey.String(id))
+	}
+
+	return attrs
+}
+
+// pathIDs maps each collection in the path template to the argument
+// following it, e.g. "/lists/%s/members/%s" gives lists and members.
+func pathIDs(op *gochimp3.Operation) map[string]string {
+	ids := make(map[string]string)
+
+	arg := 0
+	segments := strings.Split(op.PathTemplate, "/")
+	for i, s := range segments {
+		if !strings.Contains(s, "%s") {
+			continue
+		}
+		if arg >= len(op.PathArgs) {
+			break
+		}
+		if s == "%s" && i > 0 {
+			ids[segments[i-1]] = op.PathArgs[arg]
+		}
+		arg += strings.Count(s, "%s")
+	}
+
+	return ids
+}
@@ -0,0 +1,114 @@
+package gochimp3otel
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/avantarte/gochimp3"
+	"github.com/stretchr/testify/assert"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func setup(t *testing.T, handler http.HandlerFunc) (*gochimp3.API, *tracetest.InMemoryExporter) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	exporter := tracetest.NewInMemoryExporter()
+	tp := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+
+	api, err := gochimp3.NewWithOptions("apikey", gochimp3.WithBaseURL(srv.URL), gochimp3.WithAPIVersion(""))
+	if err != nil {
+		t.Fatal(err)
+	}
+	api.Middleware = append(api.Middleware, Middleware(
+		WithTracerProvider(tp),
+		WithPropagators(propagation.TraceContext{}),
+	))
+
+	return api, exporter
+}
+
+func attrs(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	m := make(map[attribute.Key]attribute.Value)
+	for _, kv := range span.Attributes {
+		m[kv.Key] = kv.Value
+	}
+	return m
+}
+
+func TestMiddlewareSpan(t *testing.T) {
+	var traceparent string
+	api, exporter := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		traceparent = r.Header.Get("Traceparent")
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Write([]byte(`{"id":"def"}`))
+	})
+
+	_, err := api.ListAddOrUpdateMember(t.Context(), "abc", "def", &gochimp3.MemberRequest{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	spans := exporter.GetSpans()
+	if !assert.Len(t, spans, 1) {
+		return
+	}
+	span := spans[0]
+	assert.Equal(t, "gochimp3.ListAddOrUpdateMember", span.Name)
+	assert.Equal(t, trace.SpanKindClient, span.SpanKind)
+	assert.Equal(t, codes.Unset, span.Status.Code)
+
+	a := attrs(span)
+	assert.Equal(t, "abc", a[ListIDKey].AsString())
+	assert.Equal(t, "PUT", a[methodKey].AsString())
+	assert.Equal(t, "/lists/%s/members/%s", a[urlTemplateKey].AsString())
+	assert.Equal(t, int64(200), a[statusCodeKey].AsInt64())
+	assert.Equal(t, "req-1", a[RequestIDKey].AsString())
+	assert.Equal(t, int64(1), a[AttemptsKey].AsInt64())
+	assert.NotContains(t, a, CampaignIDKey)
+
+	ctx := propagation.TraceContext{}.Extract(t.Context(), propagation.HeaderCarrier{"Traceparent": {traceparent}})
+	sc := trace.SpanContextFromContext(ctx)
+	assert.Equal(t, span.SpanContext.TraceID(), sc.TraceID())
+	assert.Equal(t, span.SpanContext.SpanID(), sc.SpanID())
+}
+
+func TestMiddlewareErrorSpan(t *testing.T) {
+	api, exporter := setup(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"status":404,"title":"Resource Not Found","detail":"nope"}`))
+	})
+
+	_, err := api.GetCampaign(t.Context(), "c1", nil)
+	assert.ErrorIs(t, err, gochimp3.ErrNotFound)
+
+	spans := exporter.GetSpans()
+	if !assert.Len(t, spans, 1) {
+		return
+	}
+	span := spans[0]
+	assert.Equal(t, "gochimp3.GetCampaign", span.Name)
+	assert.Equal(t, codes.Error, span.Status.Code)
+
+	a := attrs(span)
+	assert.Equal(t, "c1", a[CampaignIDKey].AsString())
+	assert.Equal(t, int64(404), a[statusCodeKey].AsInt64())
+	assert.Equal(t, "Resource Not Found", a[ErrorTypeKey].AsString())
+}
+
+func TestPathIDs(t *testing.T) {
+	op := &gochimp3.Operation{
+		PathTemplate: "/lists/%s/segments/%s/members/%s",
+		PathArgs:     []string{"l1", "s1", "m1"},
+	}
+	assert.Equal(t, map[string]string{"lists": "l1", "segments": "s1", "members": "m1"}, pathIDs(op))
+
+	op = &gochimp3.Operation{PathTemplate: "/lists"}
+	assert.Empty(t, pathIDs(op))
+}
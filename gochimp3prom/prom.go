@@ -0,0 +1,125 @@
+// Package gochimp3prom exposes gochimp3 metrics to Prometheus.
+//
+//	m := gochimp3prom.New()
+//	prometheus.MustRegister(m)
+//	api.Metrics = m
+package gochimp3prom
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/avantarte/gochimp3"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// Metrics implements both gochimp3.Metrics and prometheus.Collector.
+type Metrics struct {
+	requests    *prometheus.CounterVec
+	errors      *prometheus.CounterVec
+	latency     *prometheus.HistogramVec
+	retries     *prometheus.CounterVec
+	rateLimited *prometheus.CounterVec
+	batches     prometheus.Counter
+	batchOps    *prometheus.CounterVec
+}
+
+var (
+	_ gochimp3.Metrics     = &Metrics{}
+	_ prometheus.Collector = &Metrics{}
+)
+
+// New creates the collector, with every metric prefixed by "gochimp3_".
+func New() *Metrics {
+	return &Metrics{
+		requests: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gochimp3",
+			Name:      "requests_total",
+			Help:      "Calls made to the Mailchimp API, retries included.",
+		}, []string{"operation", "method", "code"}),
+		errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gochimp3",
+			Name:      "errors_total",
+			Help:      "Failed calls to the Mailchimp API by error type.",
+		}, []string{"operation", "type"}),
+		latency: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+			Namespace: "gochimp3",
+			Name:      "request_duration_seconds",
+			Help:      "Duration of calls to the Mailchimp API, retries included.",
+			Buckets:   gochimp3.DefaultLatencyBuckets,
+		}, []string{"operation"}),
+		retries: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gochimp3",
+			Name:      "retries_total",
+			Help:      "Attempts that failed and were retried.",
+		}, []string{"operation"}),
+		rateLimited: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gochimp3",
+			Name:      "rate_limited_total",
+			Help:      "Attempts rejected with 429 Too Many Requests.",
+		}, []string{"operation"}),
+		batches: prometheus.NewCounter(prometheus.CounterOpts{
+			Namespace: "gochimp3",
+			Name:      "batches_submitted_total",
+			Help:      "Batch operations submitted.",
+		}),
+		batchOps: prometheus.NewCounterVec(prometheus.CounterOpts{
+			Namespace: "gochimp3",
+			Name:      "batch_operations_total",
+			Help:      "Operations in batches, by state: submitted, finished or errored.",
+		}, []string{"state"}),
+	}
+}
+
+func (m *Metrics) ObserveCall(c gochimp3.CallMetrics) {
+	code := "error"
+	if c.StatusCode != 0 {
+		code = strconv.Itoa(c.StatusCode)
+	}
+
+	m.requests.WithLabelValues(c.Operation, c.Method, code).Inc()
+	m.latency.WithLabelValues(c.Operation).Observe(c.Duration.Seconds())
+	if c.ErrorType != "" {
+		m.errors.WithLabelValues(c.Operation, c.ErrorType).Inc()
+	}
+}
+
+func (m *Metrics) ObserveAttempt(a gochimp3.AttemptMetrics) {
+	if a.Retried {
+		m.retries.WithLabelValues(a.Operation).Inc()
+	}
+	if a.StatusCode == http.StatusTooManyRequests {
+		m.rateLimited.WithLabelValues(a.Operation).Inc()
+	}
+}
+
+func (m *Metrics) ObserveBatch(b gochimp3.BatchMetrics) {
+	if b.Submitted {
+		m.batches.Inc()
+		m.batchOps.WithLabelValues("submitted").Add(float64(b.TotalOperations))
+		return
+	}
+
+	m.batchOps.WithLabelValues("finished").Add(float64(b.FinishedOperations))
+	m.batchOps.WithLabelValues("errored").Add(float64(b.ErroredOperations))
+}
+
+func (m *Metrics) Describe(ch chan<- *prometheus.Desc) {
+	m.requests.Describe(ch)
+	m.errors.Describe(ch)
+	m.latency.Describe(ch)
+	m.retries.Describe(ch)
+	m.rateLimited.Describe(ch)
+	m.batches.Describe(ch)
+	m.batchOps.Describe(ch)
+}
+
+func (m *Metrics) Collect(ch chan<- prometheus.Metric) {
+	m.requests.Collect(ch)
+	m.errors.Collect(ch)
+	m.latency.Collect(ch)
+	m.retries.Collect(ch)
+	m.rateLimited.Collect(ch)
+	m.batches.Collect(ch)
+	m.batchOps.Collect(ch)
+}
@@ -0,0 +1,56 @@
+package gochimp3prom
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/avantarte/gochimp3"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMetrics(t *testing.T) {
+	m := New()
+	reg := prometheus.NewPedanticRegistry()
+	if err := reg.Register(m); err != nil {
+		t.Fatal(err)
+	}
+
+	m.ObserveAttempt(gochimp3.AttemptMetrics{Operation: "GetList", StatusCode: 429, Retried: true})
+	m.ObserveCall(gochimp3.CallMetrics{Operation: "GetList", Method: "GET", StatusCode: 200, Duration: time.Second})
+	m.ObserveCall(gochimp3.CallMetrics{Operation: "GetList", Method: "GET", StatusCode: 404, ErrorType: "Resource Not Found"})
+	m.ObserveBatch(gochimp3.BatchMetrics{Submitted: true, TotalOperations: 4})
+	m.ObserveBatch(gochimp3.BatchMetrics{FinishedOperations: 4, ErroredOperations: 1})
+
+	expected := `
+# HELP gochimp3_batch_operations_total Operations in batches, by state: submitted, finished or errored.
+# TYPE gochimp3_batch_operations_total counter
+gochimp3_batch_operations_total{state="errored"} 1
+gochimp3_batch_operations_total{state="finished"} 4
+gochimp3_batch_operations_total{state="submitted"} 4
+# HELP gochimp3_errors_total Failed calls to the Mailchimp API by error type.
+# TYPE gochimp3_errors_total counter
+gochimp3_errors_total{operation="GetList",type="Resource Not Found"} 1
+# HELP gochimp3_rate_limited_total Attempts rejected with 429 Too Many Requests.
+# TYPE gochimp3_rate_limited_total counter
+gochimp3_rate_limited_total{operation="GetList"} 1
+# HELP gochimp3_requests_total Calls made to the Mailchimp API, retries included.
+# TYPE gochimp3_requests_total counter
+gochimp3_requests_total{code="200",method="GET",operation="GetList"} 1
+gochimp3_requests_total{code="404",method="GET",operation="GetList"} 1
+# HELP gochimp3_retries_total Attempts that failed and were retried.
+# TYPE gochimp3_retries_total counter
+gochimp3_retries_total{operation="GetList"} 1
+`
+	err := testutil.GatherAndCompare(reg, strings.NewReader(expected),
+		"gochimp3_batch_operations_total",
+		"gochimp3_errors_total",
+		"gochimp3_rate_limited_total",
+		"gochimp3_requests_total",
+		"gochimp3_retries_total",
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, testutil.CollectAndCount(m, "gochimp3_request_duration_seconds"))
+}
@@ -0,0 +1,342 @@
+package gochimp3
+
+import (
+	"context"
+	"iter"
+	"strconv"
+)
+
+// DefaultPageSize is the page size used by the All* iterators when the
+// params don't set a Count.
+const DefaultPageSize = 100
+
+// paginate walks a list endpoint page by page using page.Offset and
+// page.Count, yielding every item. It stops at the first error, which is
+// yielded with the zero value of T.
+//
+// Mailchimp only supports offset pagination, so items created or deleted
+// while iterating shift the pages. Items already yielded are skipped using
+// key, and when the total shrinks the offset steps back by the same amount
+// so that no item is missed.
+func paginate[T any](ctx context.Context, page *ExtendedQueryParams, key func(T) string, fetch func(context.Context) ([]T, int, error)) iter.Seq2[T, error] {
+	if page.Count <= 0 {
+		page.Count = DefaultPageSize
+	}
+	first := *page
+
+	return func(yield func(T, error) bool) {
+		*page = first
+
+		seen := make(map[string]struct{})
+		lastTotal := -1
+
+		for {
+			if err := ctx.Err(); err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			items, total, err := fetch(ctx)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+
+			if lastTotal >= 0 && total < lastTotal {
+				page.Offset -= lastTotal - total
+				if page.Offset < 0 {
+					page.Offset = 0
+				}
+				lastTotal = total
+				continue
+			}
+			lastTotal = total
+
+			for _, item := range items {
+				if k := key(item); k != "" {
+					if _, ok := seen[k]; ok {
+						continue
+					}
+					seen[k] = struct{}{}
+				}
+
+				if !yield(item, nil) {
+					return
+				}
+			}
+
+			page.Offset += len(items)
+			if len(items) == 0 || page.Offset >= total {
+				return
+			}
+		}
+	}
+}
+
+// idKey turns a numeric ID into a paginate key, leaving out zero IDs, which
+// are most likely fields excluded from the response.
+func idKey(id int) string {
+	if id == 0 {
+		return ""
+	}
+	return strconv.Itoa(id)
+}
+
+// AllLists iterates over every list matching params, fetching pages as
+// needed.
+func (api *API) AllLists(ctx context.Context, params *ListQueryParams) iter.Seq2[ListResponse, error] {
+	var p ListQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(l ListResponse) string { return l.ID },
+		func(ctx context.Context) ([]ListResponse, int, error) {
+			response, err := api.GetLists(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Lists, response.TotalItems, nil
+		})
+}
+
+// AllCampaigns iterates over every campaign matching params, fetching pages
+// as needed.
+func (api *API) AllCampaigns(ctx context.Context, params *CampaignQueryParams) iter.Seq2[*CampaignResponse, error] {
+	var p CampaignQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(c *CampaignResponse) string { return c.ID },
+		func(ctx context.Context) ([]*CampaignResponse, int, error) {
+			response, err := api.GetCampaigns(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Campaigns, response.TotalItems, nil
+		})
+}
+
+// AllCampaignFolders iterates over every campaign folder.
+func (api *API) AllCampaignFolders(ctx context.Context, params *CampaignFolderQueryParams) iter.Seq2[CampaignFolder, error] {
+	var p CampaignFolderQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(f CampaignFolder) string { return f.ID },
+		func(ctx context.Context) ([]CampaignFolder, int, error) {
+			response, err := api.GetCampaignFolders(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Folders, response.TotalItems, nil
+		})
+}
+
+// AllTemplates iterates over every template matching params.
+func (api *API) AllTemplates(ctx context.Context, params *TemplateQueryParams) iter.Seq2[TemplateResponse, error] {
+	var p TemplateQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(t TemplateResponse) string { return idKey(int(t.ID)) },
+		func(ctx context.Context) ([]TemplateResponse, int, error) {
+			response, err := api.GetTemplates(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Templates, response.TotalItems, nil
+		})
+}
+
+// AllTemplateFolders iterates over every template folder.
+func (api *API) AllTemplateFolders(ctx context.Context, params *TemplateFolderQueryParams) iter.Seq2[TemplateFolder, error] {
+	var p TemplateFolderQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(f TemplateFolder) string { return f.ID },
+		func(ctx context.Context) ([]TemplateFolder, int, error) {
+			response, err := api.GetTemplateFolders(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Folders, response.TotalItems, nil
+		})
+}
+
+// AllBatchOperations iterates over every batch operation.
+func (api *API) AllBatchOperations(ctx context.Context, params *ListQueryParams) iter.Seq2[BatchOperationResponse, error] {
+	var p ListQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(b BatchOperationResponse) string { return b.ID },
+		func(ctx context.Context) ([]BatchOperationResponse, int, error) {
+			response, err := api.GetBatchOperations(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.BatchOperations, response.TotalItems, nil
+		})
+}
+
+// AllMembers iterates over every member of the list matching params.
+func (list *ListResponse) AllMembers(ctx context.Context, params *ListGetMembersParams) iter.Seq2[Member, error] {
+	var p ListGetMembersParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(m Member) string { return m.ID },
+		func(ctx context.Context) ([]Member, int, error) {
+			response, err := list.GetMembers(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Members, response.TotalItems, nil
+		})
+}
+
+// AllSegments iterates over every segment of the list matching params.
+func (list *ListResponse) AllSegments(ctx context.Context, params *SegmentQueryParams) iter.Seq2[Segment, error] {
+	var p SegmentQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(s Segment) string { return s.ID },
+		func(ctx context.Context) ([]Segment, int, error) {
+			response, err := list.GetSegments(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Segments, response.TotalItems, nil
+		})
+}
+
+// AllMergeFields iterates over every merge field of the list.
+func (list *ListResponse) AllMergeFields(ctx context.Context, params *MergeFieldsParams) iter.Seq2[MergeField, error] {
+	var p MergeFieldsParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(f MergeField) string { return idKey(f.MergeID) },
+		func(ctx context.Context) ([]MergeField, int, error) {
+			response, err := list.GetMergeFields(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.MergeFields, response.TotalItems, nil
+		})
+}
+
+// AllInterestCategories iterates over every interest category of the list.
+func (list *ListResponse) AllInterestCategories(ctx context.Context, params *InterestCategoriesQueryParams) iter.Seq2[InterestCategory, error] {
+	var p InterestCategoriesQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p.ExtendedQueryParams, func(c InterestCategory) string { return c.ID },
+		func(ctx context.Context) ([]InterestCategory, int, error) {
+			response, err := list.GetInterestCategories(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Categories, response.TotalItems, nil
+		})
+}
+
+// AllInterests iterates over every interest in an interest category.
+func (list *ListResponse) AllInterests(ctx context.Context, interestCategoryID string, params *ExtendedQueryParams) iter.Seq2[Interest, error] {
+	var p ExtendedQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p, func(i Interest) string { return i.ID },
+		func(ctx context.Context) ([]Interest, int, error) {
+			response, err := list.GetInterests(ctx, interestCategoryID, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Interests, response.TotalItems, nil
+		})
+}
+
+// AllAbuseReports iterates over every abuse report of the list.
+func (list *ListResponse) AllAbuseReports(ctx context.Context, params *ExtendedQueryParams) iter.Seq2[AbuseReport, error] {
+	var p ExtendedQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p, func(r AbuseReport) string { return r.ID },
+		func(ctx context.Context) ([]AbuseReport, int, error) {
+			response, err := list.GetAbuseReports(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Reports, response.TotalItems, nil
+		})
+}
+
+// AllGrowthHistory iterates over every month of the list's growth history.
+func (list *ListResponse) AllGrowthHistory(ctx context.Context, params *ExtendedQueryParams) iter.Seq2[GrowthHistory, error] {
+	var p ExtendedQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p, func(h GrowthHistory) string { return h.Month },
+		func(ctx context.Context) ([]GrowthHistory, int, error) {
+			response, err := list.GetGrowthHistory(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.History, response.TotalItems, nil
+		})
+}
+
+// AllNotes iterates over every note on the member.
+func (mem *Member) AllNotes(ctx context.Context, params *ExtendedQueryParams) iter.Seq2[MemberNoteLong, error] {
+	var p ExtendedQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p, func(n MemberNoteLong) string { return idKey(n.ID) },
+		func(ctx context.Context) ([]MemberNoteLong, int, error) {
+			response, err := mem.GetNotes(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Notes, response.TotalItems, nil
+		})
+}
+
+// AllTags iterates over every tag on the member.
+func (mem *Member) AllTags(ctx context.Context, params *ExtendedQueryParams) iter.Seq2[MemberTagLong, error] {
+	var p ExtendedQueryParams
+	if params != nil {
+		p = *params
+	}
+
+	return paginate(ctx, &p, func(t MemberTagLong) string { return idKey(t.ID) },
+		func(ctx context.Context) ([]MemberTagLong, int, error) {
+			response, err := mem.GetTags(ctx, &p)
+			if err != nil {
+				return nil, 0, err
+			}
+			return response.Tags, response.TotalItems, nil
+		})
+}
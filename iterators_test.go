@@ -0,0 +1,139 @@
+package gochimp3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// listsServer serves /lists from ids, calling onPage after every page.
+func listsServer(t *testing.T, ids *[]string, onPage func(offset int)) *API {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/lists", r.URL.Path)
+		offset, _ := strconv.Atoi(r.URL.Query().Get("offset"))
+		count, _ := strconv.Atoi(r.URL.Query().Get("count"))
+
+		all := *ids
+		end := min(offset+count, len(all))
+		start := min(offset, end)
+
+		page := ListOfLists{}
+		page.TotalItems = len(all)
+		for _, id := range all[start:end] {
+			page.Lists = append(page.Lists, ListResponse{ID: id})
+		}
+		json.NewEncoder(w).Encode(page)
+
+		if onPage != nil {
+			onPage(offset)
+		}
+	}))
+	t.Cleanup(srv.Close)
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+	return api
+}
+
+func makeIDs(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("list%03d", i)
+	}
+	return ids
+}
+
+func TestAllListsPages(t *testing.T) {
+	ids := makeIDs(25)
+	pages := 0
+	api := listsServer(t, &ids, func(int) { pages++ })
+
+	params := &ListQueryParams{}
+	params.Count = 10
+
+	var got []string
+	for list, err := range api.AllLists(t.Context(), params) {
+		fatalIf(t, err)
+		assert.NotNil(t, list.api)
+		got = append(got, list.ID)
+	}
+
+	assert.Equal(t, ids, got)
+	assert.Equal(t, 3, pages)
+	assert.Equal(t, 0, params.Offset, "caller's params must not be modified")
+}
+
+func TestAllListsItemsRemovedWhileIterating(t *testing.T) {
+	ids := makeIDs(25)
+	all := append([]string(nil), ids...)
+	api := listsServer(t, &ids, func(offset int) {
+		if offset == 0 {
+			// Delete two lists that were already returned.
+			ids = append(ids[:1], ids[3:]...)
+		}
+	})
+
+	params := &ListQueryParams{}
+	params.Count = 10
+
+	var got []string
+	for list, err := range api.AllLists(t.Context(), params) {
+		fatalIf(t, err)
+		got = append(got, list.ID)
+	}
+
+	assert.Equal(t, all, got)
+}
+
+func TestAllListsItemsAddedWhileIterating(t *testing.T) {
+	ids := makeIDs(25)
+	all := append([]string(nil), ids...)
+	api := listsServer(t, &ids, func(offset int) {
+		if offset == 0 {
+			ids = append([]string{"new"}, ids...)
+		}
+	})
+
+	params := &ListQueryParams{}
+	params.Count = 10
+
+	var got []string
+	for list, err := range api.AllLists(t.Context(), params) {
+		fatalIf(t, err)
+		got = append(got, list.ID)
+	}
+
+	assert.Equal(t, all, got)
+}
+
+func TestAllListsStopsOnCancel(t *testing.T) {
+	ids := makeIDs(25)
+	api := listsServer(t, &ids, nil)
+
+	ctx, cancel := context.WithCancel(t.Context())
+	defer cancel()
+	params := &ListQueryParams{}
+	params.Count = 10
+
+	n := 0
+	var lastErr error
+	for _, err := range api.AllLists(ctx, params) {
+		if err != nil {
+			lastErr = err
+			break
+		}
+		n++
+		if n == 10 {
+			cancel()
+		}
+	}
+
+	assert.Equal(t, 10, n)
+	assert.ErrorIs(t, lastErr, context.Canceled)
+}
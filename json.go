@@ -1,5 +1,272 @@
 package gochimp3
 
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
 const (
 	timeFormat = "2006-01-02T15:04:05-07:00"
 )
+
+// Response types keep the fields Mailchimp sends but they don't model in
+// their Extra map, so that new fields can be read before the structs catch
+// up:
+//
+//	raw := member.Extra["tags_count"]
+//
+// They do so through an UnmarshalJSON method decoding into an alias of the
+// type, which must not be defined on types embedded in others as it would be
+// promoted to the outer type. The fields of embedded types end up in the
+// outer type's Extra. Types only found in requests, and Link, have no Extra.
+
+// unmarshalWithExtra decodes data into v, a pointer to an alias of a struct
+// type, and stores the fields v doesn't know about in extra. It goes through
+// data once, decoding each field as it's read, and like json.Unmarshal
+// carries on past values of the wrong type to return the first such error.
+func unmarshalWithExtra(data []byte, v interface{}, extra *map[string]json.RawMessage) error {
+	*extra = nil
+
+	dec := json.NewDecoder(bytes.NewReader(data))
+	if tok, err := dec.Token(); err != nil || tok != json.Delim('{') {
+		// null, or not an object, which json.Unmarshal reports.
+		return json.Unmarshal(data, v)
+	}
+
+	rv := reflect.ValueOf(v).Elem()
+	fields := jsonFields(rv.Type())
+
+	var typeErr error
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		key := tok.(string)
+
+		index, ok := fields.lookup(key)
+		if !ok {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return err
+			}
+			if *extra == nil {
+				*extra = make(map[string]json.RawMessage)
+			}
+			(*extra)[key] = raw
+			continue
+		}
+
+		field, err := fieldByIndex(rv, index)
+		if err != nil {
+			return err
+		}
+		if err := dec.Decode(field.Addr().Interface()); err != nil {
+			var ute *json.UnmarshalTypeError
+			if !errors.As(err, &ute) {
+				return err
+			}
+			if typeErr == nil {
+				typeErr = err
+			}
+		}
+	}
+
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+	return typeErr
+}
+
+// fieldByIndex returns the field of v at index, allocating the embedded
+// pointers on the way.
+func fieldByIndex(v reflect.Value, index []int) (reflect.Value, error) {
+	for i, x := range index {
+		if i > 0 && v.Kind() == reflect.Pointer {
+			if v.IsNil() {
+				if !v.CanSet() {
+					return v, fmt.Errorf("gochimp3: cannot set embedded pointer to unexported struct: %v", v.Type().Elem())
+				}
+				v.Set(reflect.New(v.Type().Elem()))
+			}
+			v = v.Elem()
+		}
+		v = v.Field(x)
+	}
+	return v, nil
+}
+
+// structFields maps the JSON names of the fields of a struct type to their
+// index, the way encoding/json resolves them.
+type structFields struct {
+	exact  map[string][]int
+	folded map[string][]int
+}
+
+// lookup returns the index of the field named key, matching case-insensitively
+// as encoding/json does when no name matches exactly.
+func (fields *structFields) lookup(key string) ([]int, bool) {
+	if index, ok := fields.exact[key]; ok {
+		return index, true
+	}
+	index, ok := fields.folded[strings.ToLower(key)]
+	return index, ok
+}
+
+var fieldsCache sync.Map // reflect.Type -> *structFields
+
+// jsonFields returns the JSON fields of struct type t, including the ones
+// promoted from embedded structs.
+func jsonFields(t reflect.Type) *structFields {
+	if fields, ok := fieldsCache.Load(t); ok {
+		return fields.(*structFields)
+	}
+
+	found := make(map[string][]jsonField)
+	addJSONFields(t, nil, found)
+
+	fields := &structFields{exact: make(map[string][]int), folded: make(map[string][]int)}
+	for name, candidates := range found {
+		if f, ok := dominantField(candidates); ok {
+			fields.exact[name] = f.index
+		}
+	}
+	for name, index := range fields.exact {
+		folded := strings.ToLower(name)
+		if prev, ok := fields.folded[folded]; !ok || len(index) < len(prev) {
+			fields.folded[folded] = index
+		}
+	}
+
+	fieldsCache.Store(t, fields)
+	return fields
+}
+
+type jsonField struct {
+	index  []int
+	tagged bool
+}
+
+func addJSONFields(t reflect.Type, index []int, found map[string][]jsonField) {
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fi := append(index[:len(index):len(index)], i)
+		ft := f.Type
+		if ft.Kind() == reflect.Pointer {
+			ft = ft.Elem()
+		}
+		if f.Anonymous && name == "" && ft.Kind() == reflect.Struct {
+			addJSONFields(ft, fi, found)
+			continue
+		}
+
+		if !f.IsExported() {
+			continue
+		}
+		tagged := name != ""
+		if !tagged {
+			name = f.Name
+		}
+		found[name] = append(found[name], jsonField{index: fi, tagged: tagged})
+	}
+}
+
+// dominantField picks the field encoding/json uses among those with the same
+// name: the shallowest, then the tagged one. Ties hide them all.
+func dominantField(candidates []jsonField) (jsonField, bool) {
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if len(candidates[i].index) != len(candidates[j].index) {
+			return len(candidates[i].index) < len(candidates[j].index)
+		}
+		return candidates[i].tagged && !candidates[j].tagged
+	})
+	if len(candidates) > 1 {
+		a, b := candidates[0], candidates[1]
+		if len(a.index) == len(b.index) && a.tagged == b.tagged {
+			return jsonField{}, false
+		}
+	}
+	return candidates[0], true
+}
+
+// UnknownFieldsError is returned with API.StrictDecoding when a response
+// has fields the structs don't model.
+type UnknownFieldsError struct {
+	// Fields are the paths of the unknown fields, e.g. "members[0].tags_count".
+	Fields []string
+}
+
+func (e *UnknownFieldsError) Error() string {
+	return fmt.Sprintf("gochimp3: response has unknown fields: %s", strings.Join(e.Fields, ", "))
+}
+
+var extraType = reflect.TypeOf(map[string]json.RawMessage(nil))
+
+// unknownFields lists the keys of every Extra map reachable from v.
+func unknownFields(v interface{}) []string {
+	var fields []string
+	collectUnknown(reflect.ValueOf(v), "", &fields)
+	sort.Strings(fields)
+	return fields
+}
+
+func collectUnknown(v reflect.Value, path string, fields *[]string) {
+	switch v.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		if !v.IsNil() {
+			collectUnknown(v.Elem(), path, fields)
+		}
+	case reflect.Slice, reflect.Array:
+		for i := 0; i < v.Len(); i++ {
+			collectUnknown(v.Index(i), fmt.Sprintf("%s[%d]", path, i), fields)
+		}
+	case reflect.Map:
+		for _, k := range v.MapKeys() {
+			collectUnknown(v.MapIndex(k), fmt.Sprintf("%s[%v]", path, k), fields)
+		}
+	case reflect.Struct:
+		t := v.Type()
+		for i := 0; i < t.NumField(); i++ {
+			f := t.Field(i)
+			fv := v.Field(i)
+
+			if f.Name == "Extra" && f.Type == extraType {
+				for _, k := range fv.MapKeys() {
+					*fields = append(*fields, joinPath(path, k.String()))
+				}
+				continue
+			}
+			if !f.IsExported() && !f.Anonymous {
+				continue
+			}
+
+			name, _, _ := strings.Cut(f.Tag.Get("json"), ",")
+			if f.Anonymous && name == "" {
+				collectUnknown(fv, path, fields)
+				continue
+			}
+			if name == "" {
+				name = f.Name
+			}
+			collectUnknown(fv, joinPath(path, name), fields)
+		}
+	}
+}
+
+func joinPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
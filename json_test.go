@@ -0,0 +1,156 @@
+package gochimp3
+
+import (
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestExtraFields(t *testing.T) {
+	data := `{
+		"id": "m1",
+		"email_address": "a@b.c",
+		"tags_count": 2,
+		"stats": {"avg_open_rate": 0.5, "ecommerce_data": {"total_revenue": 1}},
+		"_links": []
+	}`
+
+	var m Member
+	fatalIf(t, json.Unmarshal([]byte(data), &m))
+	assert.Equal(t, "m1", m.ID)
+	assert.Equal(t, "a@b.c", m.EmailAddress)
+	assert.Equal(t, 0.5, m.Stats.AvgOpenRate)
+	assert.Equal(t, json.RawMessage(`2`), m.Extra["tags_count"])
+	assert.NotContains(t, m.Extra, "email_address")
+	assert.NotContains(t, m.Extra, "id")
+	assert.Equal(t, json.RawMessage(`{"total_revenue": 1}`), m.Stats.Extra["ecommerce_data"])
+
+	var c CampaignResponse
+	fatalIf(t, json.Unmarshal([]byte(`{"id":"c1","variate_settings":{"winner_criteria":"opens"},"settings":{"title":"t"}}`), &c))
+	assert.Equal(t, "c1", c.ID)
+	assert.Equal(t, "t", c.Settings.Title)
+	assert.Contains(t, c.Extra, "variate_settings")
+
+	var l ListResponse
+	fatalIf(t, json.Unmarshal([]byte(`{"id":"l1","name":"News"}`), &l))
+	assert.Equal(t, "News", l.Name)
+	assert.Nil(t, l.Extra)
+
+	// Nested objects keep theirs.
+	var nested Member
+	fatalIf(t, json.Unmarshal([]byte(`{"location":{"latitude":1.5,"region":"x"},"tags":[{"id":1,"name":"vip","date_added":"2020"}]}`), &nested))
+	assert.Equal(t, 1.5, nested.Location.Latitude)
+	assert.Equal(t, json.RawMessage(`"x"`), nested.Location.Extra["region"])
+	assert.Equal(t, "vip", nested.Tags[0].Name)
+	assert.Contains(t, nested.Tags[0].Extra, "date_added")
+	assert.Equal(t, []string{"location.region", "tags[0].date_added"}, unknownFields(&nested))
+
+	// Extra is never sent back.
+	out, err := json.Marshal(&m)
+	fatalIf(t, err)
+	assert.NotContains(t, string(out), "tags_count")
+}
+
+func TestExtraFieldsDecoding(t *testing.T) {
+	// Like json.Unmarshal, a value of the wrong type doesn't stop the rest
+	// from being decoded, keys match case-insensitively and null is a no-op.
+	var m Member
+	err := json.Unmarshal([]byte(`{"id":5,"EMAIL_ADDRESS":"a@b.c","email_type":"html","tags_count":1}`), &m)
+	var typeErr *json.UnmarshalTypeError
+	assert.True(t, errors.As(err, &typeErr), "%v", err)
+	assert.Equal(t, "a@b.c", m.EmailAddress)
+	assert.Equal(t, "html", m.EmailType)
+	assert.Empty(t, m.MemberResponse.EmailType)
+	assert.Equal(t, map[string]json.RawMessage{"tags_count": json.RawMessage(`1`)}, m.Extra)
+
+	fatalIf(t, json.Unmarshal([]byte(`null`), &m))
+	assert.Equal(t, "a@b.c", m.EmailAddress)
+
+	var s struct{ Member *Member }
+	fatalIf(t, json.Unmarshal([]byte(`{"Member":null}`), &s))
+	assert.Nil(t, s.Member)
+
+	assert.Error(t, json.Unmarshal([]byte(`[]`), &m))
+
+	// Through unexported embedded structs too.
+	var l ListResponse
+	fatalIf(t, json.Unmarshal([]byte(`{"name":"News","_links":[{"rel":"self"}]}`), &l))
+	assert.Equal(t, "News", l.Name)
+	assert.Equal(t, "self", l.Links[0].Rel)
+	assert.Nil(t, l.Extra)
+}
+
+func TestStrictDecoding(t *testing.T) {
+	page := `{"list_id":"abc","total_items":1,"members":[{"id":"m1","tags_count":1}]}`
+
+	api := streamAPI(t, page)
+	members, err := api.ListGetMembers(t.Context(), "abc", nil)
+	fatalIf(t, err)
+	assert.Contains(t, members.Members[0].Extra, "tags_count")
+
+	api.StrictDecoding = true
+	members, err = api.ListGetMembers(t.Context(), "abc", nil)
+	var unknown *UnknownFieldsError
+	if assert.True(t, errors.As(err, &unknown)) {
+		assert.Equal(t, []string{"members[0].tags_count"}, unknown.Fields)
+	}
+	assert.Nil(t, members)
+
+	_, err = api.NewListResponse("abc").StreamMembers(t.Context(), nil, func(Member) error { return nil })
+	if assert.True(t, errors.As(err, &unknown)) {
+		assert.Equal(t, []string{"members[0].tags_count"}, unknown.Fields)
+	}
+
+	// Fields of the page itself are reported too.
+	api = streamAPI(t, `{"list_id":"abc","total_items":1,"constraints":{},"members":[{"id":"m1"}]}`)
+	api.StrictDecoding = true
+	streamed := 0
+	_, err = api.NewListResponse("abc").StreamMembers(t.Context(), nil, func(Member) error { streamed++; return nil })
+	if assert.True(t, errors.As(err, &unknown)) {
+		assert.Equal(t, []string{"constraints"}, unknown.Fields)
+	}
+	assert.Equal(t, 1, streamed)
+
+	_, err = api.ListGetMembers(t.Context(), "abc", nil)
+	if assert.True(t, errors.As(err, &unknown)) {
+		assert.Equal(t, []string{"constraints"}, unknown.Fields)
+	}
+}
+
+func TestStrictDecodingTemplate(t *testing.T) {
+	// As documented in the Mailchimp API reference.
+	template := `{
+		"id": 2000094,
+		"type": "user",
+		"name": "Freddie's Jokes",
+		"drag_and_drop": true,
+		"responsive": true,
+		"category": "",
+		"date_created": "2016-06-22T19:43:22+00:00",
+		"date_edited": "2016-06-23T08:10:11+00:00",
+		"created_by": "Freddie",
+		"edited_by": "Freddie",
+		"active": true,
+		"folder_id": "",
+		"thumbnail": "https://gallery.mailchimp.com/27aac8a65e64c994c4416d6b8/template-thumbnails/2000094.png",
+		"share_url": "http://us2.mailchimp.com/templates/share?id=123",
+		"content_type": "template",
+		"_links": [{"rel": "self", "href": "https://usX.api.mailchimp.com/3.0/templates/2000094", "method": "GET", "targetSchema": "https://api.mailchimp.com/schema/3.0/Definitions/Templates/Response.json"}]
+	}`
+
+	api := streamAPI(t, template)
+	api.StrictDecoding = true
+	got, err := api.GetTemplate(t.Context(), "2000094", nil)
+	fatalIf(t, err)
+	assert.True(t, got.Active)
+	assert.Equal(t, "Freddie", got.EditedBy)
+	assert.Equal(t, "template", got.ContentType)
+
+	api = streamAPI(t, `{"templates":[`+template+`],"total_items":1,"_links":[]}`)
+	api.StrictDecoding = true
+	list, err := api.GetTemplates(t.Context(), nil)
+	fatalIf(t, err)
+	assert.True(t, list.Templates[0].Active)
+}
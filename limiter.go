@@ -0,0 +1,131 @@
+package gochimp3
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// MaxConnections is the number of simultaneous connections Mailchimp allows
+// per API key before answering with 429.
+const MaxConnections = 10
+
+// Limiter bounds the number of in-flight requests made through an API and,
+// optionally, the rate at which they start. A single Limiter may be shared
+// by several API values that use the same key.
+type Limiter struct {
+	slots chan struct{}
+
+	mu     sync.Mutex
+	rate   float64 // tokens per second, 0 means unlimited
+	burst  float64
+	tokens float64
+	last   time.Time
+
+	waits     atomic.Int64
+	waitTotal atomic.Int64
+}
+
+// NewLimiter creates a Limiter allowing at most maxInFlight concurrent
+// requests. If perSecond is positive, request starts are additionally
+// limited to that rate with bursts of up to burst requests.
+func NewLimiter(maxInFlight int, perSecond float64, burst int) *Limiter {
+	if maxInFlight <= 0 {
+		maxInFlight = MaxConnections
+	}
+	if burst <= 0 {
+		burst = 1
+	}
+
+	return &Limiter{
+		slots:  make(chan struct{}, maxInFlight),
+		rate:   perSecond,
+		burst:  float64(burst),
+		tokens: float64(burst),
+		last:   time.Now(),
+	}
+}
+
+// LimiterStats reports how long callers have been queued by a Limiter.
+type LimiterStats struct {
+	InFlight int
+
+	// Waits counts the calls to Acquire that had to wait for a slot or for
+	// the rate, and TotalWait the time they spent waiting.
+	Waits     int64
+	TotalWait time.Duration
+}
+
+// Stats returns a snapshot of the limiter's counters.
+func (l *Limiter) Stats() LimiterStats {
+	return LimiterStats{
+		InFlight:  len(l.slots),
+		Waits:     l.waits.Load(),
+		TotalWait: time.Duration(l.waitTotal.Load()),
+	}
+}
+
+// Acquire blocks until a request may start or ctx is done. It returns the
+// time spent waiting. Every successful Acquire must be paired with Release.
+func (l *Limiter) Acquire(ctx context.Context) (time.Duration, error) {
+	start := time.Now()
+
+	blocked, err := l.take(ctx)
+	if err == nil {
+		select {
+		case l.slots <- struct{}{}:
+		default:
+			blocked = true
+			select {
+			case l.slots <- struct{}{}:
+			case <-ctx.Done():
+				err = ctx.Err()
+			}
+		}
+	}
+
+	wait := time.Since(start)
+	if blocked {
+		l.waits.Add(1)
+		l.waitTotal.Add(int64(wait))
+	}
+
+	return wait, err
+}
+
+// Release frees the slot taken by Acquire.
+func (l *Limiter) Release() {
+	<-l.slots
+}
+
+// take consumes a token from the bucket, waiting for one to be refilled if
+// necessary. It reports whether it had to wait.
+func (l *Limiter) take(ctx context.Context) (bool, error) {
+	if l.rate <= 0 {
+		return false, nil
+	}
+
+	for waited := false; ; waited = true {
+		l.mu.Lock()
+		now := time.Now()
+		l.tokens += now.Sub(l.last).Seconds() * l.rate
+		if l.tokens > l.burst {
+			l.tokens = l.burst
+		}
+		l.last = now
+
+		if l.tokens >= 1 {
+			l.tokens--
+			l.mu.Unlock()
+			return waited, nil
+		}
+
+		wait := time.Duration((1 - l.tokens) / l.rate * float64(time.Second))
+		l.mu.Unlock()
+
+		if err := sleep(ctx, wait); err != nil {
+			return true, err
+		}
+	}
+}
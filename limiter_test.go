@@ -0,0 +1,105 @@
+package gochimp3
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLimiterCapsInFlightRequests(t *testing.T) {
+	var current, peak atomic.Int32
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		n := current.Add(1)
+		defer current.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+
+	api := testAPI()
+	api.Debug = false
+	api.Limiter = NewLimiter(2, 0, 0)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := api.requestOk(t.Context(), "GET", "/somewhere")
+			assert.Nil(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.LessOrEqual(t, peak.Load(), int32(2))
+	stats := api.Limiter.Stats()
+	// The first two never wait.
+	assert.Less(t, stats.Waits, int64(7))
+	assert.Equal(t, 0, stats.InFlight)
+}
+
+func TestLimiterStatsCountOnlyWaits(t *testing.T) {
+	l := NewLimiter(1, 0, 0)
+	for i := 0; i < 3; i++ {
+		_, err := l.Acquire(t.Context())
+		fatalIf(t, err)
+		l.Release()
+	}
+	assert.Equal(t, LimiterStats{}, l.Stats())
+
+	_, err := l.Acquire(t.Context())
+	fatalIf(t, err)
+
+	acquired := make(chan time.Duration)
+	go func() {
+		wait, err := l.Acquire(t.Context())
+		assert.NoError(t, err)
+		acquired <- wait
+	}()
+	time.Sleep(10 * time.Millisecond)
+	l.Release()
+	wait := <-acquired
+	l.Release()
+
+	stats := l.Stats()
+	assert.Equal(t, int64(1), stats.Waits)
+	assert.Equal(t, wait, stats.TotalWait)
+	assert.GreaterOrEqual(t, wait, 10*time.Millisecond)
+}
+
+func TestLimiterCancelWhileQueued(t *testing.T) {
+	l := NewLimiter(1, 0, 0)
+	_, err := l.Acquire(t.Context())
+	fatalIf(t, err)
+	defer l.Release()
+
+	ctx, cancel := context.WithTimeout(t.Context(), 10*time.Millisecond)
+	defer cancel()
+
+	_, err = l.Acquire(ctx)
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 1, l.Stats().InFlight)
+}
+
+func TestLimiterRate(t *testing.T) {
+	l := NewLimiter(10, 100, 1)
+
+	start := time.Now()
+	for i := 0; i < 5; i++ {
+		_, err := l.Acquire(t.Context())
+		fatalIf(t, err)
+		l.Release()
+	}
+
+	// One token is available up front, the other four refill at 10ms each.
+	assert.GreaterOrEqual(t, time.Since(start), 35*time.Millisecond)
+}
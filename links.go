@@ -0,0 +1,67 @@
+package gochimp3
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+)
+
+// ErrForeignLink is returned by Follow for links outside the API's
+// endpoint, which are never sent the credentials.
+var ErrForeignLink = errors.New("gochimp3: link is outside the API endpoint")
+
+// Follow requests link with its method, decoding a successful response into
+// out, which may be nil. body is sent for links that take one, such as
+// "create" or "update"; their schema is in link.Schema.
+//
+//	list, _ := api.GetList(ctx, id, nil)
+//	if parent := list.Link("parent"); parent != nil {
+//		var lists gochimp3.ListOfLists
+//		err = api.Follow(ctx, *parent, nil, &lists)
+//	}
+func (api *API) Follow(ctx context.Context, link Link, body, out interface{}) error {
+	path, query, err := api.linkPath(link.Href)
+	if err != nil {
+		return err
+	}
+
+	method := strings.ToUpper(link.Method)
+	if method == "" {
+		method = "GET"
+	}
+
+	var params QueryParams
+	if len(query) > 0 {
+		params = query
+	}
+
+	return api.do(ctx, newOperation("Follow", method, path), params, body, out)
+}
+
+// linkPath returns the path of href relative to the API's endpoint, and its
+// query string.
+func (api *API) linkPath(href string) (string, Query, error) {
+	endpoint, err := url.Parse(api.endpoint)
+	if err != nil {
+		return "", nil, err
+	}
+	u, err := url.Parse(href)
+	if err != nil {
+		return "", nil, err
+	}
+
+	base := strings.TrimSuffix(endpoint.EscapedPath(), "/")
+	path := u.EscapedPath()
+	if !strings.EqualFold(u.Scheme, endpoint.Scheme) || !strings.EqualFold(u.Host, endpoint.Host) ||
+		(path != base && !strings.HasPrefix(path, base+"/")) {
+		return "", nil, ErrForeignLink
+	}
+
+	query := make(Query)
+	for k, v := range u.Query() {
+		query[k] = strings.Join(v, ",")
+	}
+
+	return strings.TrimPrefix(path, base), query, nil
+}
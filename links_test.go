@@ -0,0 +1,78 @@
+package gochimp3
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFollow(t *testing.T) {
+	var srv *httptest.Server
+	srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		_, pass, _ := r.BasicAuth()
+		assert.Equal(t, "apikey", pass)
+
+		switch r.Method + " " + r.URL.Path {
+		case "GET /3.0/lists/abc":
+			json.NewEncoder(w).Encode(map[string]interface{}{
+				"id": "abc",
+				"_links": []map[string]string{
+					{"rel": "self", "href": srv.URL + "/3.0/lists/abc", "method": "GET"},
+					{"rel": "parent", "href": srv.URL + "/3.0/lists?count=5", "method": "GET"},
+					{"rel": "update", "href": srv.URL + "/3.0/lists/abc", "method": "PATCH"},
+					{"rel": "elsewhere", "href": "https://example.com/3.0/lists", "method": "GET"},
+				},
+			})
+		case "GET /3.0/lists":
+			assert.Equal(t, "5", r.URL.Query().Get("count"))
+			w.Write([]byte(`{"lists":[{"id":"abc"}],"total_items":1}`))
+		case "PATCH /3.0/lists/abc":
+			data, _ := io.ReadAll(r.Body)
+			assert.JSONEq(t, `{"name":"Renamed"}`, string(data))
+			w.Write([]byte(`{"id":"abc","name":"Renamed"}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL))
+	fatalIf(t, err)
+
+	list, err := api.GetList(t.Context(), "abc", nil)
+	fatalIf(t, err)
+
+	var rels []string
+	for _, l := range list.Actions() {
+		rels = append(rels, l.Rel)
+	}
+	assert.Equal(t, []string{"parent", "update", "elsewhere"}, rels)
+	assert.Nil(t, list.Link("delete"))
+
+	var lists ListOfLists
+	fatalIf(t, api.Follow(t.Context(), *list.Link("parent"), nil, &lists))
+	assert.Equal(t, 1, lists.TotalItems)
+
+	var updated ListResponse
+	fatalIf(t, api.Follow(t.Context(), *list.Link("update"), map[string]string{"name": "Renamed"}, &updated))
+	assert.Equal(t, "Renamed", updated.Name)
+
+	err = api.Follow(t.Context(), *list.Link("elsewhere"), nil, nil)
+	assert.ErrorIs(t, err, ErrForeignLink)
+
+	err = api.Follow(t.Context(), Link{Href: srv.URL + "/3.0evil/lists"}, nil, nil)
+	assert.ErrorIs(t, err, ErrForeignLink)
+}
+
+func TestLinkRel(t *testing.T) {
+	var b BatchOperationResponse
+	fatalIf(t, json.Unmarshal([]byte(`{"_links":[{"rel":"parent","href":"x","method":"GET"}]}`), &b))
+	if assert.NotNil(t, b.Link("parent")) {
+		assert.Equal(t, "x", b.Link("parent").Href)
+	}
+	assert.Nil(t, b.Extra)
+}
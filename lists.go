@@ -2,8 +2,10 @@ package gochimp3
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
+	"strconv"
+	"time"
 )
 
 const (
@@ -34,26 +36,27 @@ const (
 type ListQueryParams struct {
 	ExtendedQueryParams
 
-	BeforeDateCreated      string
-	SinceDateCreated       string
-	BeforeCampaignLastSent string
-	SinceCampaignLastSent  string
-	Email                  string
+	BeforeDateCreated      time.Time `url:"before_date_created"`
+	SinceDateCreated       time.Time `url:"since_date_created"`
+	BeforeCampaignLastSent time.Time `url:"before_campaign_last_sent"`
+	SinceCampaignLastSent  time.Time `url:"since_campaign_last_sent"`
+	Email                  string    `url:"email"`
 }
 
 func (q ListQueryParams) Params() map[string]string {
-	m := q.ExtendedQueryParams.Params()
-	m["before_date_created"] = q.BeforeDateCreated
-	m["since_date_created"] = q.SinceDateCreated
-	m["before_campaign_last_sent"] = q.BeforeCampaignLastSent
-	m["since_campaign_last_sent"] = q.SinceCampaignLastSent
-	m["email"] = q.Email
-	return m
+	return encodeQuery(&q)
 }
 
 type ListOfLists struct {
 	baseList
 	Lists []ListResponse `json:"lists"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfLists) UnmarshalJSON(data []byte) error {
+	type alias ListOfLists
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type ListCreationRequest struct {
@@ -67,13 +70,29 @@ type ListCreationRequest struct {
 	EmailTypeOption     bool             `json:"email_type_option"`
 }
 
+func (req ListCreationRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("name", req.Name)
+	errs.required("permission_reminder", req.PermissionReminder)
+	errs.required("contact.company", req.Contact.Company)
+	errs.required("contact.address1", req.Contact.Address1)
+	errs.required("contact.city", req.Contact.City)
+	errs.required("contact.country", req.Contact.Country)
+	errs.required("campaign_defaults.from_name", req.CampaignDefaults.FromName)
+	errs.required("campaign_defaults.from_email", req.CampaignDefaults.FromEmail)
+	errs.required("campaign_defaults.subject", req.CampaignDefaults.Subject)
+	errs.required("campaign_defaults.language", req.CampaignDefaults.Language)
+	return errs.err()
+}
+
 type ListResponse struct {
 	ListCreationRequest
 	withLinks
+	noValidate
 
 	ID                string   `json:"id"`
 	WebID             int64    `json:"web_id"`
-	DateCreated       string   `json:"date_created"`
+	DateCreated       Time     `json:"date_created"`
 	ListRating        int      `json:"list_rating"`
 	SubscribeURLShort string   `json:"subscribe_url_short"`
 	SubscribeURLLong  string   `json:"subscribe_url_long"`
@@ -81,9 +100,16 @@ type ListResponse struct {
 	Modules           []string `json:"modules"`
 	Stats             Stats    `json:"stats"`
 
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (list *ListResponse) UnmarshalJSON(data []byte) error {
+	type alias ListResponse
+	return unmarshalWithExtra(data, (*alias)(list), &list.Extra)
+}
+
 func (list *ListResponse) CanMakeRequest() error {
 	if list.ID == "" {
 		return errors.New("No ID provided on list")
@@ -100,15 +126,22 @@ type Stats struct {
 	UnsubscribeCountSinceSend int     `json:"unsubscribe_count_since_send"`
 	CleanedCountSinceSend     int     `json:"cleaned_count_since_send"`
 	CampaignCount             int     `json:"campaign_count"`
-	CampaignLastSent          string  `json:"campaign_last_sent"`
+	CampaignLastSent          Time    `json:"campaign_last_sent"`
 	MergeFieldCount           int     `json:"merge_field_count"`
 	AvgSubRate                float64 `json:"avg_sub_rate"`
 	AvgUnsubRate              float64 `json:"avg_unsub_rate"`
 	TargetSubRate             float64 `json:"target_sub_rate"`
 	OpenRate                  float64 `json:"open_rate"`
 	ClickRate                 float64 `json:"click_rate"`
-	LastSubDate               string  `json:"last_sub_date"`
-	LastUnsubDate             string  `json:"last_unsub_date"`
+	LastSubDate               Time    `json:"last_sub_date"`
+	LastUnsubDate             Time    `json:"last_unsub_date"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *Stats) UnmarshalJSON(data []byte) error {
+	type alias Stats
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type CampaignDefaults struct {
@@ -116,12 +149,19 @@ type CampaignDefaults struct {
 	FromEmail string `json:"from_email"`
 	Subject   string `json:"subject"`
 	Language  string `json:"language"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *CampaignDefaults) UnmarshalJSON(data []byte) error {
+	type alias CampaignDefaults
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (api *API) GetLists(ctx context.Context, params *ListQueryParams) (*ListOfLists, error) {
 	response := new(ListOfLists)
 
-	err := api.request(ctx, "GET", lists_path, params, nil, response)
+	err := api.do(ctx, newOperation("GetLists", "GET", lists_path), params, nil, response)
 	if err != nil {
 		return nil, err
 	}
@@ -145,32 +185,32 @@ func (api *API) NewListResponse(id string) *ListResponse {
 }
 
 func (api *API) GetList(ctx context.Context, id string, params *BasicQueryParams) (*ListResponse, error) {
-	endpoint := fmt.Sprintf(single_list_path, id)
+	op := newOperation("GetList", "GET", single_list_path, id)
 
 	response := new(ListResponse)
 	response.api = api
 
-	return response, api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, api.do(ctx, op, params, nil, response)
 }
 
 func (api *API) CreateList(ctx context.Context, body *ListCreationRequest) (*ListResponse, error) {
 	response := new(ListResponse)
 	response.api = api
-	return response, api.request(ctx, "POST", lists_path, nil, body, response)
+	return response, api.do(ctx, newOperation("CreateList", "POST", lists_path), nil, body, response)
 }
 
 func (api *API) UpdateList(ctx context.Context, id string, body *ListCreationRequest) (*ListResponse, error) {
-	endpoint := fmt.Sprintf(single_list_path, id)
+	op := newOperation("UpdateList", "PATCH", single_list_path, id)
 
 	response := new(ListResponse)
 	response.api = api
 
-	return response, api.request(ctx, "PATCH", endpoint, nil, body, response)
+	return response, api.do(ctx, op, nil, body, response)
 }
 
 func (api *API) DeleteList(ctx context.Context, id string) (bool, error) {
-	endpoint := fmt.Sprintf(single_list_path, id)
-	return api.requestOk(ctx, "DELETE", endpoint)
+	op := newOperation("DeleteList", "DELETE", single_list_path, id)
+	return api.doOk(ctx, op)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -182,6 +222,13 @@ type ListOfAbuseReports struct {
 
 	ListID  string        `json:"list_id"`
 	Reports []AbuseReport `json:"abuse_reports"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfAbuseReports) UnmarshalJSON(data []byte) error {
+	type alias ListOfAbuseReports
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type AbuseReport struct {
@@ -190,9 +237,16 @@ type AbuseReport struct {
 	ListID       string `json:"list_id"`
 	EmailID      string `json:"email_id"`
 	EmailAddress string `json:"email_address"`
-	Date         string `json:"date"`
+	Date         Time   `json:"date"`
 
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *AbuseReport) UnmarshalJSON(data []byte) error {
+	type alias AbuseReport
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (list *ListResponse) GetAbuseReports(ctx context.Context, params *ExtendedQueryParams) (*ListOfAbuseReports, error) {
@@ -200,10 +254,10 @@ func (list *ListResponse) GetAbuseReports(ctx context.Context, params *ExtendedQ
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(abuse_reports_path, list.ID)
+	op := newOperation("ListResponse.GetAbuseReports", "GET", abuse_reports_path, list.ID)
 	response := new(ListOfAbuseReports)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (list *ListResponse) GetAbuseReport(ctx context.Context, id string, params *ExtendedQueryParams) (*AbuseReport, error) {
@@ -211,10 +265,10 @@ func (list *ListResponse) GetAbuseReport(ctx context.Context, id string, params
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_abuse_report_path, list.ID, id)
+	op := newOperation("ListResponse.GetAbuseReport", "GET", single_abuse_report_path, list.ID, id)
 	response := new(AbuseReport)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -226,21 +280,35 @@ type ListOfActivity struct {
 
 	ListID     string     `json:"list_id"`
 	Activities []Activity `json:"activity"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfActivity) UnmarshalJSON(data []byte) error {
+	type alias ListOfActivity
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type Activity struct {
-	Day             string `json:"day"`
-	EmailsSent      int    `json:"emails_sent"`
-	UniqueOpens     int    `json:"unique_opens"`
-	RecipientClicks int    `json:"recipient_clicks"`
-	HardBounce      int    `json:"hard_bounce"`
-	SoftBounce      int    `json:"soft_bounce"`
-	Subs            int    `json:"subs"`
-	Unsubs          int    `json:"unsubs"`
-	OtherAdds       int    `json:"other_adds"`
-	OtherRemoves    int    `json:"other_removes"`
+	Day             Time `json:"day"`
+	EmailsSent      int  `json:"emails_sent"`
+	UniqueOpens     int  `json:"unique_opens"`
+	RecipientClicks int  `json:"recipient_clicks"`
+	HardBounce      int  `json:"hard_bounce"`
+	SoftBounce      int  `json:"soft_bounce"`
+	Subs            int  `json:"subs"`
+	Unsubs          int  `json:"unsubs"`
+	OtherAdds       int  `json:"other_adds"`
+	OtherRemoves    int  `json:"other_removes"`
 
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *Activity) UnmarshalJSON(data []byte) error {
+	type alias Activity
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (list *ListResponse) GetActivity(ctx context.Context, params *BasicQueryParams) (*ListOfActivity, error) {
@@ -248,10 +316,10 @@ func (list *ListResponse) GetActivity(ctx context.Context, params *BasicQueryPar
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(activity_path, list.ID)
+	op := newOperation("ListResponse.GetActivity", "GET", activity_path, list.ID)
 	response := new(ListOfActivity)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -263,6 +331,13 @@ type ListOfClients struct {
 
 	ListID  string   `json:"list_id"`
 	Clients []Client `json:"clients"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfClients) UnmarshalJSON(data []byte) error {
+	type alias ListOfClients
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type Client struct {
@@ -271,6 +346,13 @@ type Client struct {
 	ListID  string `json:"list_id"`
 
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *Client) UnmarshalJSON(data []byte) error {
+	type alias Client
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (list *ListResponse) GetClients(ctx context.Context, params *BasicQueryParams) (*ListOfClients, error) {
@@ -278,10 +360,10 @@ func (list *ListResponse) GetClients(ctx context.Context, params *BasicQueryPara
 		return nil, errors.New("No ID provided on list")
 	}
 
-	endpoint := fmt.Sprintf(clients_path, list.ID)
+	op := newOperation("ListResponse.GetClients", "GET", clients_path, list.ID)
 	response := new(ListOfClients)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -293,6 +375,13 @@ type ListOfGrownHistory struct {
 
 	ListID  string          `json:"list_id"`
 	History []GrowthHistory `json:"history"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfGrownHistory) UnmarshalJSON(data []byte) error {
+	type alias ListOfGrownHistory
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type GrowthHistory struct {
@@ -303,6 +392,13 @@ type GrowthHistory struct {
 	OptIns   int    `json:"optins"`
 
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *GrowthHistory) UnmarshalJSON(data []byte) error {
+	type alias GrowthHistory
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (list *ListResponse) GetGrowthHistory(ctx context.Context, params *ExtendedQueryParams) (*ListOfGrownHistory, error) {
@@ -310,10 +406,10 @@ func (list *ListResponse) GetGrowthHistory(ctx context.Context, params *Extended
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(history_path, list.ID)
+	op := newOperation("ListResponse.GetGrowthHistory", "GET", history_path, list.ID)
 	response := new(ListOfGrownHistory)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (list *ListResponse) GetGrowthHistoryForMonth(ctx context.Context, month string, params *BasicQueryParams) (*GrowthHistory, error) {
@@ -321,10 +417,10 @@ func (list *ListResponse) GetGrowthHistoryForMonth(ctx context.Context, month st
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_history_path, list.ID, month)
+	op := newOperation("ListResponse.GetGrowthHistoryForMonth", "GET", single_history_path, list.ID, month)
 	response := new(GrowthHistory)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -335,6 +431,13 @@ type ListOfInterestCategories struct {
 	baseList
 	ListID     string             `json:"list_id"`
 	Categories []InterestCategory `json:"categories"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfInterestCategories) UnmarshalJSON(data []byte) error {
+	type alias ListOfInterestCategories
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type InterestCategoryRequest struct {
@@ -343,16 +446,41 @@ type InterestCategoryRequest struct {
 	Type         string `json:"type"`
 }
 
+// Validate checks req for creating an interest category. Updates only check
+// the fields they set.
+func (req InterestCategoryRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("title", req.Title)
+	errs.required("type", req.Type)
+	return errs.merge(req.validatePartial())
+}
+
+func (req InterestCategoryRequest) validatePartial() error {
+	var errs fieldErrors
+	if req.Type != "" {
+		errs.oneOf("type", req.Type, "checkboxes", "dropdown", "radio", "hidden")
+	}
+	return errs.err()
+}
+
 type InterestCategory struct {
 	InterestCategoryRequest
+	noValidate
 
 	ListID string `json:"list_id"`
 	ID     string `json:"id"`
 
 	withLinks
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (interestCategory *InterestCategory) UnmarshalJSON(data []byte) error {
+	type alias InterestCategory
+	return unmarshalWithExtra(data, (*alias)(interestCategory), &interestCategory.Extra)
+}
+
 func (interestCatgory *InterestCategory) CanMakeRequest() error {
 	if interestCatgory.ID == "" {
 		return errors.New("No ID provided on interest category")
@@ -364,13 +492,11 @@ func (interestCatgory *InterestCategory) CanMakeRequest() error {
 type InterestCategoriesQueryParams struct {
 	ExtendedQueryParams
 
-	Type string `json:"type"`
+	Type string `url:"type"`
 }
 
 func (q *InterestCategoriesQueryParams) Params() map[string]string {
-	m := q.ExtendedQueryParams.Params()
-	m["type"] = q.Type
-	return m
+	return encodeQuery(q)
 }
 
 func (list *ListResponse) GetInterestCategories(ctx context.Context, params *InterestCategoriesQueryParams) (*ListOfInterestCategories, error) {
@@ -378,10 +504,10 @@ func (list *ListResponse) GetInterestCategories(ctx context.Context, params *Int
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(interest_categories_path, list.ID)
+	op := newOperation("ListResponse.GetInterestCategories", "GET", interest_categories_path, list.ID)
 	response := new(ListOfInterestCategories)
 
-	err := list.api.request(ctx, "GET", endpoint, params, nil, response)
+	err := list.api.do(ctx, op, params, nil, response)
 	if err != nil {
 		return nil, err
 	}
@@ -398,11 +524,11 @@ func (list *ListResponse) GetInterestCategory(ctx context.Context, id string, pa
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_interest_category_path, list.ID, id)
+	op := newOperation("ListResponse.GetInterestCategory", "GET", single_interest_category_path, list.ID, id)
 	response := new(InterestCategory)
 	response.api = list.api
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (list *ListResponse) CreateInterestCategory(ctx context.Context, body *InterestCategoryRequest) (*InterestCategory, error) {
@@ -410,11 +536,11 @@ func (list *ListResponse) CreateInterestCategory(ctx context.Context, body *Inte
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(interest_categories_path, list.ID)
+	op := newOperation("ListResponse.CreateInterestCategory", "POST", interest_categories_path, list.ID)
 	response := new(InterestCategory)
 	response.api = list.api
 
-	return response, list.api.request(ctx, "POST", endpoint, nil, body, response)
+	return response, list.api.do(ctx, op, nil, body, response)
 }
 
 func (list *ListResponse) UpdateInterestCategory(ctx context.Context, id string, body *InterestCategoryRequest) (*InterestCategory, error) {
@@ -422,11 +548,11 @@ func (list *ListResponse) UpdateInterestCategory(ctx context.Context, id string,
 		return nil, errors.New("No ID provided on list")
 	}
 
-	endpoint := fmt.Sprintf(single_interest_category_path, list.ID, id)
+	op := newOperation("ListResponse.UpdateInterestCategory", "PATCH", single_interest_category_path, list.ID, id)
 	response := new(InterestCategory)
 	response.api = list.api
 
-	return response, list.api.request(ctx, "PATCH", endpoint, nil, body, response)
+	return response, list.api.do(ctx, op, nil, body, response)
 }
 
 func (list *ListResponse) DeleteInterestCategory(ctx context.Context, id string) (bool, error) {
@@ -434,8 +560,8 @@ func (list *ListResponse) DeleteInterestCategory(ctx context.Context, id string)
 		return false, errors.New("No ID provided on list")
 	}
 
-	endpoint := fmt.Sprintf(single_interest_category_path, list.ID, id)
-	return list.api.requestOk(ctx, "DELETE", endpoint)
+	op := newOperation("ListResponse.DeleteInterestCategory", "DELETE", single_interest_category_path, list.ID, id)
+	return list.api.doOk(ctx, op)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -448,6 +574,13 @@ type ListOfInterests struct {
 	ListID     string     `json:"list_id"`
 	TotalItems int        `json:"total_items"`
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfInterests) UnmarshalJSON(data []byte) error {
+	type alias ListOfInterests
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type Interest struct {
@@ -457,6 +590,13 @@ type Interest struct {
 	Name         string `json:"name"`
 	DisplayOrder int    `json:"display_order"`
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *Interest) UnmarshalJSON(data []byte) error {
+	type alias Interest
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type InterestRequest struct {
@@ -464,15 +604,21 @@ type InterestRequest struct {
 	DisplayOrder int    `json:"display_order"`
 }
 
+func (req InterestRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("name", req.Name)
+	return errs.err()
+}
+
 func (list *ListResponse) GetInterests(ctx context.Context, interestCategoryID string, params *ExtendedQueryParams) (*ListOfInterests, error) {
 	if err := list.CanMakeRequest(); err != nil {
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(interests_path, list.ID, interestCategoryID)
+	op := newOperation("ListResponse.GetInterests", "GET", interests_path, list.ID, interestCategoryID)
 	response := new(ListOfInterests)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (list *ListResponse) GetInterest(ctx context.Context, interestCategoryID, interestID string, params *BasicQueryParams) (*Interest, error) {
@@ -480,10 +626,10 @@ func (list *ListResponse) GetInterest(ctx context.Context, interestCategoryID, i
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_interest_path, list.ID, interestCategoryID, interestID)
+	op := newOperation("ListResponse.GetInterest", "GET", single_interest_path, list.ID, interestCategoryID, interestID)
 	response := new(Interest)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (interestCategory *InterestCategory) CreateInterest(ctx context.Context, body *InterestRequest) (*Interest, error) {
@@ -491,10 +637,10 @@ func (interestCategory *InterestCategory) CreateInterest(ctx context.Context, bo
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(interests_path, interestCategory.ListID, interestCategory.ID)
+	op := newOperation("InterestCategory.CreateInterest", "POST", interests_path, interestCategory.ListID, interestCategory.ID)
 	response := new(Interest)
 
-	return response, interestCategory.api.request(ctx, "POST", endpoint, nil, body, response)
+	return response, interestCategory.api.do(ctx, op, nil, body, response)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -504,6 +650,13 @@ type BatchSubscribeMembersError struct {
 	EmailAddress string `json:"email_address"`
 	ErrorMessage string `json:"error"`
 	ErrorCode    string `json:"error_code"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *BatchSubscribeMembersError) UnmarshalJSON(data []byte) error {
+	type alias BatchSubscribeMembersError
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type BatchSubscribeMembersResponse struct {
@@ -516,23 +669,38 @@ type BatchSubscribeMembersResponse struct {
 	TotalUpdated   int                          `json:"total_updated"`
 	ErrorCount     int                          `json:"error_count"`
 
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (v *BatchSubscribeMembersResponse) UnmarshalJSON(data []byte) error {
+	type alias BatchSubscribeMembersResponse
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
+}
+
 type BatchSubscribeMembersRequest struct {
 	Members        []MemberRequest `json:"members"`
 	UpdateExisting bool            `json:"update_existing"`
 }
 
+func (req BatchSubscribeMembersRequest) Validate() error {
+	var errs fieldErrors
+	for i, member := range req.Members {
+		errs.nested("members["+strconv.Itoa(i)+"]", member.Validate())
+	}
+	return errs.err()
+}
+
 func (list *ListResponse) BatchSubscribeMembers(ctx context.Context, body *BatchSubscribeMembersRequest) (*BatchSubscribeMembersResponse, error) {
 	if err := list.CanMakeRequest(); err != nil {
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(lists_batch_subscribe_members, list.ID)
+	op := newOperation("ListResponse.BatchSubscribeMembers", "POST", lists_batch_subscribe_members, list.ID)
 	response := new(BatchSubscribeMembersResponse)
 
-	return response, list.api.request(ctx, "POST", endpoint, nil, body, response)
+	return response, list.api.do(ctx, op, nil, body, response)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -542,14 +710,19 @@ func (list *ListResponse) BatchSubscribeMembers(ctx context.Context, body *Batch
 type MergeFieldsParams struct {
 	ExtendedQueryParams
 
-	Type     string `json:"type"`
-	Required bool   `json:"required"`
+	Type     string `url:"type"`
+	Required bool   `url:"required"`
+}
+
+func (q *MergeFieldsParams) Params() map[string]string {
+	return encodeQuery(q)
 }
 
 type MergeFieldParams struct {
 	BasicQueryParams
 
-	MergeID string `json:"_"`
+	// MergeID is part of the path, not the query.
+	MergeID string `url:"-"`
 }
 
 type ListOfMergeFields struct {
@@ -557,6 +730,13 @@ type ListOfMergeFields struct {
 
 	ListID      string       `json:"list_id"`
 	MergeFields []MergeField `json:"merge_fields"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfMergeFields) UnmarshalJSON(data []byte) error {
+	type alias ListOfMergeFields
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MergeField struct {
@@ -573,6 +753,13 @@ type MergeField struct {
 	ListID       string            `json:"list_id"`
 
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MergeField) UnmarshalJSON(data []byte) error {
+	type alias MergeField
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MergeFieldOptions struct {
@@ -581,6 +768,13 @@ type MergeFieldOptions struct {
 	DateFormat     string   `json:"date_format"`
 	Choices        []string `json:"choices,omitempty"`
 	Size           int      `json:"size"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MergeFieldOptions) UnmarshalJSON(data []byte) error {
+	type alias MergeFieldOptions
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MergeFieldRequest struct {
@@ -591,7 +785,7 @@ type MergeFieldRequest struct {
 	Name string `json:"name"`
 
 	// The type for the merge field.
-	// Possible Values: text, number, address, phone, date, url, image, url, radio, dropdown, birthday, zip
+	// Possible Values: text, number, address, phone, date, url, imageurl, radio, dropdown, birthday, zip
 	Type string `json:"type"`
 
 	// The boolean value if the merge field is required.
@@ -613,15 +807,22 @@ type MergeFieldRequest struct {
 	HelpText string `json:"help_text"`
 }
 
+func (req MergeFieldRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("name", req.Name)
+	errs.oneOf("type", req.Type, "text", "number", "address", "phone", "date", "url", "imageurl", "radio", "dropdown", "birthday", "zip")
+	return errs.err()
+}
+
 func (list *ListResponse) GetMergeFields(ctx context.Context, params *MergeFieldsParams) (*ListOfMergeFields, error) {
 	if err := list.CanMakeRequest(); err != nil {
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(merge_fields_path, list.ID)
+	op := newOperation("ListResponse.GetMergeFields", "GET", merge_fields_path, list.ID)
 	response := new(ListOfMergeFields)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (list *ListResponse) GetMergeField(ctx context.Context, params *MergeFieldParams) (*MergeField, error) {
@@ -629,10 +830,10 @@ func (list *ListResponse) GetMergeField(ctx context.Context, params *MergeFieldP
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(merge_field_path, list.ID, params.MergeID)
+	op := newOperation("ListResponse.GetMergeField", "GET", merge_field_path, list.ID, params.MergeID)
 	response := new(MergeField)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (list *ListResponse) CreateMergeField(ctx context.Context, body *MergeFieldRequest) (*MergeField, error) {
@@ -640,8 +841,8 @@ func (list *ListResponse) CreateMergeField(ctx context.Context, body *MergeField
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(merge_fields_path, list.ID)
+	op := newOperation("ListResponse.CreateMergeField", "POST", merge_fields_path, list.ID)
 	response := new(MergeField)
 
-	return response, list.api.request(ctx, "POST", endpoint, nil, body, response)
+	return response, list.api.do(ctx, op, nil, body, response)
 }
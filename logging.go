@@ -0,0 +1,220 @@
+package gochimp3
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"net/http/httputil"
+	"net/url"
+	"reflect"
+	"time"
+)
+
+// DefaultLogRedact lists the fields masked in logs unless API.LogRedact is
+// set. It covers the personal data Mailchimp requests and responses carry.
+var DefaultLogRedact = []string{
+	"email_address",
+	"email",
+	"query",
+	"merge_fields",
+	"ip_signup",
+	"ip_opt",
+	"location",
+	"test_emails",
+	"members_to_add",
+	"members_to_remove",
+}
+
+// DefaultLogBodyLimit is the number of bytes of each body logged unless
+// API.LogBodyLimit is set.
+const DefaultLogBodyLimit = 4096
+
+const redacted = "[REDACTED]"
+
+func (api *API) logRedact() map[string]bool {
+	fields := api.LogRedact
+	if fields == nil {
+		fields = DefaultLogRedact
+	}
+
+	m := make(map[string]bool, len(fields))
+	for _, f := range fields {
+		m[f] = true
+	}
+	return m
+}
+
+// logAttempt records the outcome of a single round trip. Successful calls are
+// logged at info level, retries and client errors at warn level, and server
+// or transport errors at error level. status is that of the attempt's
+// response, 0 if it got none.
+func (api *API) logAttempt(ctx context.Context, c *call, attempt, status int, err error, retry bool, wait time.Duration) {
+	if api.Logger == nil {
+		return
+	}
+
+	level := slog.LevelInfo
+	msg := "mailchimp request"
+	switch {
+	case retry:
+		level = slog.LevelWarn
+		msg = "mailchimp request failed, retrying"
+	case err != nil && status >= 400 && status < 500:
+		level = slog.LevelWarn
+		msg = "mailchimp request failed"
+	case err != nil:
+		level = slog.LevelError
+		msg = "mailchimp request failed"
+	}
+
+	if !api.Logger.Enabled(ctx, level) {
+		return
+	}
+
+	attrs := []slog.Attr{
+		slog.String("method", c.op.Method),
+		slog.String("path", c.path),
+		slog.Int("status", status),
+		slog.Duration("duration", c.op.Info.Latency),
+		slog.Int("attempt", attempt),
+	}
+	if c.op.Info.RequestID != "" {
+		attrs = append(attrs, slog.String("request_id", c.op.Info.RequestID))
+	}
+	if c.op.Info.QueueWait > 0 {
+		attrs = append(attrs, slog.Duration("queue_wait", c.op.Info.QueueWait))
+	}
+	if q := api.redactQuery(c.op.Params); len(q) > 0 {
+		attrs = append(attrs, slog.Any("query", q))
+	}
+	if retry {
+		attrs = append(attrs, slog.Duration("retry_in", wait))
+	}
+	if err != nil {
+		attrs = append(attrs, errorAttrs(err)...)
+	}
+
+	api.Logger.LogAttrs(ctx, level, msg, attrs...)
+}
+
+// errorAttrs describes err without its detail message, which often repeats
+// the email address the call was about.
+func errorAttrs(err error) []slog.Attr {
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		attrs := []slog.Attr{slog.String("error_type", apiErr.Title)}
+		if apiErr.Instance != "" {
+			attrs = append(attrs, slog.String("error_instance", apiErr.Instance))
+		}
+		return attrs
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return []slog.Attr{slog.String("error_type", http.StatusText(httpErr.StatusCode))}
+	}
+
+	// A *url.Error repeats the request URL, query included.
+	var urlErr *url.Error
+	if errors.As(err, &urlErr) {
+		return []slog.Attr{slog.String("error", urlErr.Op+": "+urlErr.Err.Error())}
+	}
+
+	return []slog.Attr{slog.String("error", err.Error())}
+}
+
+// logBody logs a request or response body at debug level, masking the
+// redacted fields and truncating it to the body limit.
+func (api *API) logBody(ctx context.Context, c *call, msg string, data []byte) {
+	if api.Logger == nil || len(data) == 0 || !api.Logger.Enabled(ctx, slog.LevelDebug) {
+		return
+	}
+
+	limit := api.LogBodyLimit
+	if limit <= 0 {
+		limit = DefaultLogBodyLimit
+	}
+
+	body := redactJSON(data, api.logRedact())
+	truncated := len(body) > limit
+	if truncated {
+		body = body[:limit]
+	}
+
+	api.Logger.LogAttrs(ctx, slog.LevelDebug, msg,
+		slog.String("method", c.op.Method),
+		slog.String("path", c.path),
+		slog.String("body", string(body)),
+		slog.Bool("truncated", truncated),
+	)
+}
+
+func (api *API) redactQuery(params QueryParams) map[string]string {
+	if params == nil || reflect.ValueOf(params).IsNil() {
+		return nil
+	}
+
+	fields := api.logRedact()
+	q := make(map[string]string)
+	for k, v := range params.Params() {
+		if v == "" {
+			continue
+		}
+		if fields[k] {
+			v = redacted
+		}
+		q[k] = v
+	}
+	return q
+}
+
+// redactJSON masks the values of the given fields at any depth. Bodies that
+// aren't JSON are returned as is.
+func redactJSON(data []byte, fields map[string]bool) []byte {
+	var v interface{}
+	if err := json.Unmarshal(data, &v); err != nil {
+		return data
+	}
+
+	out, err := json.Marshal(redactValue(v, fields))
+	if err != nil {
+		return data
+	}
+	return out
+}
+
+func redactValue(v interface{}, fields map[string]bool) interface{} {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for k, child := range v {
+			if fields[k] {
+				v[k] = redacted
+			} else {
+				v[k] = redactValue(child, fields)
+			}
+		}
+	case []interface{}:
+		for i, child := range v {
+			v[i] = redactValue(child, fields)
+		}
+	}
+	return v
+}
+
+// dumpRequest is httputil.DumpRequestOut without the credentials.
+func dumpRequest(req *http.Request, body []byte) string {
+	clone := req.Clone(req.Context())
+	if clone.Header.Get("Authorization") != "" {
+		clone.Header.Set("Authorization", redacted)
+	}
+	if body != nil {
+		clone.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	dump, _ := httputil.DumpRequestOut(clone, body != nil)
+	return string(dump)
+}
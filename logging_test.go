@@ -0,0 +1,157 @@
+package gochimp3
+
+import (
+	"bytes"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"log"
+	"log/slog"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func logRecords(t *testing.T, buf *bytes.Buffer) []map[string]interface{} {
+	var records []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		record := make(map[string]interface{})
+		fatalIf(t, json.Unmarshal([]byte(line), &record))
+		records = append(records, record)
+	}
+	return records
+}
+
+func TestStructuredLogging(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Write([]byte(`{"id": "abc", "email_address": "a@example.com", "merge_fields": {"FNAME": "Ann"}}`))
+	}
+
+	var buf bytes.Buffer
+	api := testAPI()
+	api.Debug = false
+	api.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	body := &MemberRequest{EmailAddress: "a@example.com", Status: "subscribed"}
+	err := api.request(t.Context(), "PUT", "/somewhere", nil, body, nil)
+	fatalIf(t, err)
+
+	assert.NotContains(t, buf.String(), "a@example.com")
+	assert.NotContains(t, buf.String(), "Ann")
+	assert.NotContains(t, buf.String(), "apikey")
+
+	records := logRecords(t, &buf)
+	assert.Len(t, records, 3)
+
+	assert.Equal(t, "mailchimp request body", records[0]["msg"])
+	assert.Contains(t, records[0]["body"], `"email_address":"[REDACTED]"`)
+	assert.Contains(t, records[0]["body"], `"status":"subscribed"`)
+
+	assert.Equal(t, "mailchimp response body", records[1]["msg"])
+	assert.Contains(t, records[1]["body"], `"merge_fields":"[REDACTED]"`)
+
+	summary := records[2]
+	assert.Equal(t, "INFO", summary["level"])
+	assert.Equal(t, "PUT", summary["method"])
+	assert.Equal(t, "/somewhere", summary["path"])
+	assert.Equal(t, float64(200), summary["status"])
+	assert.Equal(t, float64(1), summary["attempt"])
+	assert.Equal(t, "req-1", summary["request_id"])
+	assert.Contains(t, summary, "duration")
+}
+
+func TestLoggingBodyLimitAndLevel(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		http.Error(w, `{"title": "Resource Not Found", "status": 404, "detail": "a@example.com"}`, 404)
+	}
+
+	var buf bytes.Buffer
+	api := testAPI()
+	api.Debug = false
+	api.LogBodyLimit = 10
+	api.Logger = slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+
+	params := &SearchMembersQueryParams{Query: "a@example.com"}
+	err := api.request(t.Context(), "GET", "/somewhere", params, nil, nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	records := logRecords(t, &buf)
+	assert.Len(t, records, 2)
+	assert.Equal(t, true, records[0]["truncated"])
+	assert.Len(t, records[0]["body"], 10)
+
+	assert.Equal(t, "WARN", records[1]["level"])
+	assert.Equal(t, "Resource Not Found", records[1]["error_type"])
+	assert.Equal(t, map[string]interface{}{"query": "[REDACTED]"}, records[1]["query"])
+	assert.NotContains(t, buf.String(), "a@example.com")
+}
+
+func TestDebugDumpHidesCredentials(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {}
+
+	var buf bytes.Buffer
+	out := log.Writer()
+	log.SetOutput(&buf)
+	defer log.SetOutput(out)
+
+	api := testAPI()
+	err := api.request(t.Context(), "POST", "/somewhere", nil, map[string]string{"a": "b"}, nil)
+	fatalIf(t, err)
+
+	auth := base64.StdEncoding.EncodeToString([]byte("gochimp3:apikey"))
+	assert.NotContains(t, buf.String(), auth)
+	assert.Contains(t, buf.String(), "Authorization: [REDACTED]")
+	assert.Contains(t, buf.String(), `{"a":"b"}`)
+}
+
+type failingTransport struct{}
+
+func (failingTransport) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("connection refused")
+}
+
+func TestLoggingTransportErrorHidesQuery(t *testing.T) {
+	var buf bytes.Buffer
+	api, err := NewWithOptions("apikey", WithBaseURL("http://mailchimp.invalid"), WithAPIVersion(""),
+		WithHTTPClient(&http.Client{Transport: failingTransport{}}))
+	fatalIf(t, err)
+	api.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+
+	list := &ListResponse{api: api, ID: "abc"}
+	_, err = list.SearchMembers(t.Context(), &SearchMembersQueryParams{Query: "a@example.com"})
+	assert.Error(t, err)
+
+	assert.NotContains(t, buf.String(), "a@example.com")
+	records := logRecords(t, &buf)
+	if assert.Len(t, records, 1) {
+		assert.Equal(t, "Get: connection refused", records[0]["error"])
+		assert.Equal(t, map[string]interface{}{"list_id": "abc", "query": "[REDACTED]"}, records[0]["query"])
+	}
+}
+
+func TestLoggingTransportErrorAfterResponse(t *testing.T) {
+	var buf bytes.Buffer
+	api, err := NewWithOptions("apikey", WithBaseURL("http://mailchimp.invalid"), WithAPIVersion(""),
+		WithHTTPClient(&http.Client{Transport: rateLimitedThenReset()}))
+	fatalIf(t, err)
+	api.Logger = slog.New(slog.NewJSONHandler(&buf, nil))
+	api.Retry = testRetryPolicy()
+	api.Retry.MaxAttempts = 2
+
+	_, err = api.GetList(t.Context(), "abc", nil)
+	assert.Error(t, err)
+
+	records := logRecords(t, &buf)
+	if assert.Len(t, records, 2) {
+		assert.Equal(t, "WARN", records[0]["level"])
+		assert.Equal(t, float64(429), records[0]["status"])
+		assert.Equal(t, "req-429", records[0]["request_id"])
+
+		assert.Equal(t, "ERROR", records[1]["level"])
+		assert.Equal(t, float64(0), records[1]["status"])
+		assert.NotContains(t, records[1], "request_id")
+	}
+}
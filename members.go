@@ -3,10 +3,13 @@ package gochimp3
 import (
 	"context"
 	"crypto/md5"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"io"
+	"strconv"
 	"strings"
+	"time"
 )
 
 const (
@@ -30,6 +33,13 @@ type ListOfMembers struct {
 
 	ListID  string   `json:"list_id"`
 	Members []Member `json:"members"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfMembers) UnmarshalJSON(data []byte) error {
+	type alias ListOfMembers
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MemberResponse struct {
@@ -45,14 +55,14 @@ type MemberResponse struct {
 	IPOpt           string                 `json:"ip_opt,omitempty"`
 	IPSignup        string                 `json:"ip_signup,omitempty"`
 	Tags            []MemberTag            `json:"tags,omitempty"`
-	TimestampSignup string                 `json:"timestamp_signup,omitempty"`
-	TimestampOpt    string                 `json:"timestamp_opt,omitempty"`
+	TimestampSignup Time                   `json:"timestamp_signup,omitzero"`
+	TimestampOpt    Time                   `json:"timestamp_opt,omitzero"`
 }
 
 type MemberRequest struct {
 	EmailAddress         string                 `json:"email_address"`
 	EmailType            string                 `json:"email_type,omitempty"`
-	Status               string                 `json:"status"`
+	Status               string                 `json:"status,omitempty"`
 	StatusIfNew          string                 `json:"status_if_new,omitempty"`
 	MergeFields          map[string]interface{} `json:"merge_fields,omitempty"`
 	Interests            map[string]bool        `json:"interests,omitempty"`
@@ -67,6 +77,31 @@ type MemberRequest struct {
 	TimestampOpt         string                 `json:"timestamp_opt,omitempty"`
 }
 
+// memberStatuses are the values allowed for Status and StatusIfNew.
+var memberStatuses = []string{"subscribed", "unsubscribed", "cleaned", "pending", "transactional"}
+
+// Validate checks req for creating a member. Updates and upserts only check
+// the fields they set.
+func (req MemberRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("status", req.Status)
+	return errs.merge(req.validatePartial())
+}
+
+func (req MemberRequest) validatePartial() error {
+	var errs fieldErrors
+	if req.Status != "" {
+		errs.oneOf("status", req.Status, memberStatuses...)
+	}
+	if req.StatusIfNew != "" {
+		errs.oneOf("status_if_new", req.StatusIfNew, memberStatuses...)
+	}
+	if req.EmailType != "" {
+		errs.oneOf("email_type", req.EmailType, "html", "text")
+	}
+	return errs.err()
+}
+
 type Member struct {
 	MemberResponse
 
@@ -76,23 +111,39 @@ type Member struct {
 	EmailType     string          `json:"email_type"`
 	Stats         MemberStats     `json:"stats"`
 	MemberRating  int             `json:"member_rating"`
-	LastChanged   string          `json:"last_changed"`
+	LastChanged   Time            `json:"last_changed"`
 	EmailClient   string          `json:"email_client"`
 	LastNote      MemberNoteShort `json:"last_note"`
 
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (mem *Member) UnmarshalJSON(data []byte) error {
+	type alias Member
+	return unmarshalWithExtra(data, (*alias)(mem), &mem.Extra)
+}
+
 type ListGetMembersParams struct {
 	ExtendedQueryParams
 
-	UnsubscribedSince  string `json:"unsubscribed_since"`
-	SinceTimestampOpt  string `json:"since_timestamp_opt"`
-	BeforeTimestampOpt string `json:"before_timestamp_opt"`
-	SinceLastChanged   string `json:"since_last_changed"`
-	BeforeLastChanged  string `json:"before_last_changed"`
-	SinceLastCampaign  string `json:"since_last_campaign"`
-	UniqueEmailID      string `json:"unique_email_id"`
+	EmailType          string    `url:"email_type"`
+	UnsubscribedSince  time.Time `url:"unsubscribed_since"`
+	SinceTimestampOpt  time.Time `url:"since_timestamp_opt"`
+	BeforeTimestampOpt time.Time `url:"before_timestamp_opt"`
+	SinceLastChanged   time.Time `url:"since_last_changed"`
+	BeforeLastChanged  time.Time `url:"before_last_changed"`
+	SinceLastCampaign  time.Time `url:"since_last_campaign"`
+	UniqueEmailID      string    `url:"unique_email_id"`
+	VIPOnly            bool      `url:"vip_only"`
+	InterestCategoryID string    `url:"interest_category_id"`
+	InterestIDs        []string  `url:"interest_ids"`
+	InterestMatch      string    `url:"interest_match"` // one of "any", "all" or "none"
+}
+
+func (q *ListGetMembersParams) Params() map[string]string {
+	return encodeQuery(q)
 }
 
 func (mem *Member) CanMakeRequest() error {
@@ -130,6 +181,13 @@ func (api *API) MemberForApiCalls(listId, email string) *Member {
 type MemberStats struct {
 	AvgOpenRate  float64 `json:"avg_open_rate"`
 	AvgClickRate float64 `json:"avg_click_rate"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MemberStats) UnmarshalJSON(data []byte) error {
+	type alias MemberStats
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MemberLocation struct {
@@ -139,6 +197,13 @@ type MemberLocation struct {
 	DSTOffset   int     `json:"dstoff"`
 	CountryCode string  `json:"country_code"`
 	Timezone    string  `json:"timezone"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MemberLocation) UnmarshalJSON(data []byte) error {
+	type alias MemberLocation
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MarketingPermissions []MarketingPermission
@@ -147,42 +212,129 @@ type MarketingPermission struct {
 	MarketingPermissionID string `json:"marketing_permission_id"`
 	Text                  string `json:"text"`
 	Enabled               bool   `json:"enabled"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MarketingPermission) UnmarshalJSON(data []byte) error {
+	type alias MarketingPermission
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MemberNoteShort struct {
 	ID        int    `json:"note_id"`
-	CreatedAt string `json:"created_at"`
+	CreatedAt Time   `json:"created_at"`
 	CreatedBy string `json:"created_by"`
 	Note      string `json:"note"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MemberNoteShort) UnmarshalJSON(data []byte) error {
+	type alias MemberNoteShort
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MemberTag struct {
 	ID   int    `json:"id"`
 	Name string `json:"name"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MemberTag) UnmarshalJSON(data []byte) error {
+	type alias MemberTag
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (list *ListResponse) GetMembers(ctx context.Context, params *ListGetMembersParams) (*ListOfMembers, error) {
+	return list.getMembers(ctx, "ListResponse.GetMembers", params)
+}
+
+// getMembers is GetMembers reported to middleware under the given operation
+// name.
+func (list *ListResponse) getMembers(ctx context.Context, name string, params *ListGetMembersParams) (*ListOfMembers, error) {
 	if err := list.CanMakeRequest(); err != nil {
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(members_path, list.ID)
+	op := newOperation(name, "GET", members_path, list.ID)
 	response := new(ListOfMembers)
 
-	err := list.api.request(ctx, "GET", endpoint, params, nil, response)
+	err := list.api.do(ctx, op, params, nil, response)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, m := range response.Members {
-		m.api = list.api
+	for i := range response.Members {
+		response.Members[i].api = list.api
 	}
 
 	return response, nil
 }
 
+// StreamMembers fetches a page of members like GetMembers, but hands each
+// member to fn as soon as it is decoded instead of holding the whole page in
+// memory. The returned ListOfMembers has everything but Members. It stops at
+// the first error returned by fn, which is returned as is.
+//
+// fn runs while the response is being read, so the connection and the
+// API.Limiter slot of the call are held until StreamMembers returns: keep
+// fn quick, and don't make calls from it through an API whose Limiter may
+// have no other slot free, as they would wait forever.
+func (list *ListResponse) StreamMembers(ctx context.Context, params *ListGetMembersParams, fn func(Member) error) (*ListOfMembers, error) {
+	if err := list.CanMakeRequest(); err != nil {
+		return nil, err
+	}
+
+	op := newOperation("ListResponse.StreamMembers", "GET", members_path, list.ID)
+	response := &memberStream{api: list.api, fn: fn}
+
+	err := list.api.do(ctx, op, params, nil, response)
+	if err != nil {
+		return nil, err
+	}
+
+	return &response.list, nil
+}
+
+// memberStream decodes a ListOfMembers, handing over members one by one.
+type memberStream struct {
+	list ListOfMembers
+	fn   func(Member) error
+	api  *API
+}
+
+func (s *memberStream) decodeStream(dec *json.Decoder) error {
+	i := 0
+	err := decodeStreamed(dec, &s.list, "members", func(dec *json.Decoder) error {
+		var m Member
+		if err := dec.Decode(&m); err != nil {
+			return err
+		}
+		if s.api.StrictDecoding {
+			if fields := unknownFields(&m); len(fields) > 0 {
+				for j, f := range fields {
+					fields[j] = fmt.Sprintf("members[%d].%s", i, f)
+				}
+				return &UnknownFieldsError{Fields: fields}
+			}
+		}
+		i++
+
+		m.api = s.api
+		return s.fn(m)
+	})
+	if err != nil {
+		return err
+	}
+
+	// s.list being unexported, the caller's check doesn't reach it.
+	return s.api.checkUnknownFields(&s.list)
+}
+
 func (api *API) ListGetMembers(ctx context.Context, listID string, params *ListGetMembersParams) (*ListOfMembers, error) {
-	return api.NewListResponse(listID).GetMembers(ctx, params)
+	return api.NewListResponse(listID).getMembers(ctx, "ListGetMembers", params)
 }
 
 func (list *ListResponse) GetMember(ctx context.Context, id string, params *BasicQueryParams) (*Member, error) {
@@ -190,11 +342,11 @@ func (list *ListResponse) GetMember(ctx context.Context, id string, params *Basi
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_member_path, list.ID, id)
+	op := newOperation("ListResponse.GetMember", "GET", single_member_path, list.ID, id)
 	response := new(Member)
 	response.api = list.api
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (list *ListResponse) CreateMember(ctx context.Context, body *MemberRequest) (*Member, error) {
@@ -202,11 +354,11 @@ func (list *ListResponse) CreateMember(ctx context.Context, body *MemberRequest)
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(members_path, list.ID)
+	op := newOperation("ListResponse.CreateMember", "POST", members_path, list.ID)
 	response := new(Member)
 	response.api = list.api
 
-	return response, list.api.request(ctx, "POST", endpoint, nil, body, response)
+	return response, list.api.do(ctx, op, nil, body, response)
 }
 
 func (list *ListResponse) UpdateMember(ctx context.Context, id string, body *MemberRequest) (*Member, error) {
@@ -214,23 +366,29 @@ func (list *ListResponse) UpdateMember(ctx context.Context, id string, body *Mem
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_member_path, list.ID, id)
+	op := newOperation("ListResponse.UpdateMember", "PATCH", single_member_path, list.ID, id)
 	response := new(Member)
 	response.api = list.api
 
-	return response, list.api.request(ctx, "PATCH", endpoint, nil, body, response)
+	return response, list.api.do(ctx, op, nil, body, response)
 }
 
 func (list *ListResponse) AddOrUpdateMember(ctx context.Context, id string, body *MemberRequest) (*Member, error) {
+	return list.addOrUpdateMember(ctx, "ListResponse.AddOrUpdateMember", id, body)
+}
+
+// addOrUpdateMember is AddOrUpdateMember reported to middleware under the
+// given operation name.
+func (list *ListResponse) addOrUpdateMember(ctx context.Context, name, id string, body *MemberRequest) (*Member, error) {
 	if err := list.CanMakeRequest(); err != nil {
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_member_path, list.ID, id)
+	op := newOperation(name, "PUT", single_member_path, list.ID, id)
 	response := new(Member)
 	response.api = list.api
 
-	return response, list.api.request(ctx, "PUT", endpoint, nil, body, response)
+	return response, list.api.do(ctx, op, nil, body, response)
 }
 
 func (api *API) ListAddOrUpdateMember(ctx context.Context, listID, memberID string, body *MemberRequest) (*Member, error) {
@@ -247,7 +405,7 @@ func (api *API) ListAddOrUpdateMember(ctx context.Context, listID, memberID stri
 		}
 	}
 
-	return api.NewListResponse(listID).AddOrUpdateMember(ctx, memberID, body)
+	return api.NewListResponse(listID).addOrUpdateMember(ctx, "ListAddOrUpdateMember", memberID, body)
 }
 
 func (list *ListResponse) DeleteMember(ctx context.Context, id string) (bool, error) {
@@ -255,8 +413,8 @@ func (list *ListResponse) DeleteMember(ctx context.Context, id string) (bool, er
 		return false, err
 	}
 
-	endpoint := fmt.Sprintf(single_member_path, list.ID, id)
-	return list.api.requestOk(ctx, "DELETE", endpoint)
+	op := newOperation("ListResponse.DeleteMember", "DELETE", single_member_path, list.ID, id)
+	return list.api.doOk(ctx, op)
 }
 
 func (list *ListResponse) DeleteMemberPermanent(ctx context.Context, id string) (bool, error) {
@@ -264,8 +422,8 @@ func (list *ListResponse) DeleteMemberPermanent(ctx context.Context, id string)
 		return false, err
 	}
 
-	endpoint := fmt.Sprintf(delete_permanent_path, list.ID, id)
-	return list.api.requestOk(ctx, "POST", endpoint)
+	op := newOperation("ListResponse.DeleteMemberPermanent", "POST", delete_permanent_path, list.ID, id)
+	return list.api.doOk(ctx, op)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -278,16 +436,30 @@ type ListOfMemberActivity struct {
 	EmailID  string     `json:"email_id"`
 	ListID   string     `json:"list_id"`
 	Activity []Activity `json:"activity"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfMemberActivity) UnmarshalJSON(data []byte) error {
+	type alias ListOfMemberActivity
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MemberActivity struct {
 	Action         string `json:"action"`
-	Timestamp      string `json:"timestamp"`
+	Timestamp      Time   `json:"timestamp"`
 	URL            string `json:"url"`
 	Type           string `json:"type"`
 	CampaignID     string `json:"campaign_id"`
 	Title          string `json:"title"`
 	ParentCampaign string `json:"parent_campaign"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MemberActivity) UnmarshalJSON(data []byte) error {
+	type alias MemberActivity
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (mem *Member) GetActivity(ctx context.Context, params *BasicQueryParams) (*ListOfMemberActivity, error) {
@@ -295,10 +467,10 @@ func (mem *Member) GetActivity(ctx context.Context, params *BasicQueryParams) (*
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(member_activity_path, mem.ListID, mem.ID)
+	op := newOperation("Member.GetActivity", "GET", member_activity_path, mem.ListID, mem.ID)
 	response := new(ListOfMemberActivity)
 
-	return response, mem.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, mem.api.do(ctx, op, params, nil, response)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -311,13 +483,27 @@ type ListOfMemberGoals struct {
 	EmailID string       `json:"email_id"`
 	ListID  string       `json:"list_id"`
 	Goals   []MemberGoal `json:"goals"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfMemberGoals) UnmarshalJSON(data []byte) error {
+	type alias ListOfMemberGoals
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MemberGoal struct {
 	ID            int    `json:"goal_id"`
 	Event         string `json:"event"`
-	LastVisitedAt string `json:"last_visited_at"`
+	LastVisitedAt Time   `json:"last_visited_at"`
 	Data          string `json:"data"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MemberGoal) UnmarshalJSON(data []byte) error {
+	type alias MemberGoal
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (mem *Member) GetGoals(ctx context.Context, params *BasicQueryParams) (*ListOfMemberGoals, error) {
@@ -325,10 +511,10 @@ func (mem *Member) GetGoals(ctx context.Context, params *BasicQueryParams) (*Lis
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(member_goals_path, mem.ListID, mem.ID)
+	op := newOperation("Member.GetGoals", "GET", member_goals_path, mem.ListID, mem.ID)
 	response := new(ListOfMemberGoals)
 
-	return response, mem.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, mem.api.do(ctx, op, params, nil, response)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -341,18 +527,32 @@ type ListOfMemberNotes struct {
 	EmailID string           `json:"email_id"`
 	ListID  string           `json:"list_id"`
 	Notes   []MemberNoteLong `json:"notes"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfMemberNotes) UnmarshalJSON(data []byte) error {
+	type alias ListOfMemberNotes
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type MemberNoteLong struct {
 	ID        int    `json:"id"`
-	CreatedAt string `json:"created_at"`
+	CreatedAt Time   `json:"created_at"`
 	CreatedBy string `json:"created_by"`
-	UpdatedAt string `json:"updated_at"`
+	UpdatedAt Time   `json:"updated_at"`
 	Note      string `json:"note"`
 	ListID    string `json:"list_id"`
 	EmailID   string `json:"email_id"`
 
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MemberNoteLong) UnmarshalJSON(data []byte) error {
+	type alias MemberNoteLong
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (mem *Member) GetNotes(ctx context.Context, params *ExtendedQueryParams) (*ListOfMemberNotes, error) {
@@ -360,10 +560,10 @@ func (mem *Member) GetNotes(ctx context.Context, params *ExtendedQueryParams) (*
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(member_notes_path, mem.ListID, mem.ID)
+	op := newOperation("Member.GetNotes", "GET", member_notes_path, mem.ListID, mem.ID)
 	response := new(ListOfMemberNotes)
 
-	return response, mem.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, mem.api.do(ctx, op, params, nil, response)
 }
 
 func (mem *Member) CreateNote(ctx context.Context, msg string) (*MemberNoteLong, error) {
@@ -371,14 +571,14 @@ func (mem *Member) CreateNote(ctx context.Context, msg string) (*MemberNoteLong,
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(member_notes_path, mem.ListID, mem.ID)
+	op := newOperation("Member.CreateNote", "POST", member_notes_path, mem.ListID, mem.ID)
 	response := new(MemberNoteLong)
 
 	body := struct{ Note string }{
 		Note: msg,
 	}
 
-	return response, mem.api.request(ctx, "POST", endpoint, nil, &body, response)
+	return response, mem.api.do(ctx, op, nil, &body, response)
 }
 
 func (mem *Member) UpdateNote(ctx context.Context, id, msg string) (*MemberNoteLong, error) {
@@ -386,14 +586,14 @@ func (mem *Member) UpdateNote(ctx context.Context, id, msg string) (*MemberNoteL
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_member_note_path, mem.ListID, mem.ID, id)
+	op := newOperation("Member.UpdateNote", "PATCH", single_member_note_path, mem.ListID, mem.ID, id)
 	response := new(MemberNoteLong)
 
 	body := struct{ Note string }{
 		Note: msg,
 	}
 
-	return response, mem.api.request(ctx, "PATCH", endpoint, nil, &body, response)
+	return response, mem.api.do(ctx, op, nil, &body, response)
 }
 
 func (mem *Member) GetNote(ctx context.Context, id string, params *BasicQueryParams) (*MemberNoteLong, error) {
@@ -401,10 +601,10 @@ func (mem *Member) GetNote(ctx context.Context, id string, params *BasicQueryPar
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_member_note_path, mem.ListID, mem.ID, id)
+	op := newOperation("Member.GetNote", "GET", single_member_note_path, mem.ListID, mem.ID, id)
 	response := new(MemberNoteLong)
 
-	return response, mem.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, mem.api.do(ctx, op, params, nil, response)
 }
 
 func (mem *Member) DeleteNote(ctx context.Context, id string) (bool, error) {
@@ -412,8 +612,8 @@ func (mem *Member) DeleteNote(ctx context.Context, id string) (bool, error) {
 		return false, err
 	}
 
-	endpoint := fmt.Sprintf(single_member_note_path, mem.ListID, mem.ID, id)
-	return mem.api.requestOk(ctx, "DELETE", endpoint)
+	op := newOperation("Member.DeleteNote", "DELETE", single_member_note_path, mem.ListID, mem.ID, id)
+	return mem.api.doOk(ctx, op)
 }
 
 // ------------------------------------------------------------------------------------------------
@@ -424,6 +624,13 @@ type ListOfMemberTags struct {
 	baseList
 
 	Tags []MemberTagLong `json:"tags"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfMemberTags) UnmarshalJSON(data []byte) error {
+	type alias ListOfMemberTags
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type UpdateMemberTag struct {
@@ -431,13 +638,39 @@ type UpdateMemberTag struct {
 	Status string `json:"status"`
 }
 
+func (tag UpdateMemberTag) Validate() error {
+	var errs fieldErrors
+	errs.required("name", tag.Name)
+	errs.oneOf("status", tag.Status, "active", "inactive")
+	return errs.err()
+}
+
+type memberTagsRequest struct {
+	Tags []UpdateMemberTag `json:"tags,omitempty"`
+}
+
+func (req memberTagsRequest) Validate() error {
+	var errs fieldErrors
+	for i, tag := range req.Tags {
+		errs.nested("tags["+strconv.Itoa(i)+"]", tag.Validate())
+	}
+	return errs.err()
+}
+
 type MemberTagLong struct {
-	ID        int     `json:"id"`
-	Name      string  `json:"name"`
-	DataAdded *string `json:"date_added,omitempty"`
-	Status    string  `json:"status,omitempty"`
+	ID        int    `json:"id"`
+	Name      string `json:"name"`
+	DataAdded Time   `json:"date_added,omitzero"`
+	Status    string `json:"status,omitempty"`
 
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *MemberTagLong) UnmarshalJSON(data []byte) error {
+	type alias MemberTagLong
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (mem *Member) GetTags(ctx context.Context, params *ExtendedQueryParams) (*ListOfMemberTags, error) {
@@ -445,10 +678,10 @@ func (mem *Member) GetTags(ctx context.Context, params *ExtendedQueryParams) (*L
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(member_tags_path, mem.ListID, mem.ID)
+	op := newOperation("Member.GetTags", "GET", member_tags_path, mem.ListID, mem.ID)
 	response := new(ListOfMemberTags)
 
-	return response, mem.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, mem.api.do(ctx, op, params, nil, response)
 }
 
 func (mem *Member) UpdateTags(ctx context.Context, tags []UpdateMemberTag) (*ListOfMemberTags, error) {
@@ -456,16 +689,12 @@ func (mem *Member) UpdateTags(ctx context.Context, tags []UpdateMemberTag) (*Lis
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(member_tags_path, mem.ListID, mem.ID)
+	op := newOperation("Member.UpdateTags", "POST", member_tags_path, mem.ListID, mem.ID)
 	response := new(ListOfMemberTags)
 
-	body := struct {
-		Tags []UpdateMemberTag `json:"tags,omitempty"`
-	}{
-		Tags: tags,
-	}
+	body := memberTagsRequest{Tags: tags}
 
-	return response, mem.api.request(ctx, "POST", endpoint, nil, &body, response)
+	return response, mem.api.do(ctx, op, nil, &body, response)
 }
 
 // EmailToMemberID converts given email address to subscriber_hash to be used as ID in Member API.
@@ -0,0 +1,288 @@
+package gochimp3
+
+import (
+	"context"
+	"errors"
+	"expvar"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Metrics receives measurements of the calls made through an API. See
+// ExpvarMetrics and the gochimp3prom package for ready made adapters.
+// Implementations must be safe for concurrent use.
+type Metrics interface {
+	// ObserveCall is called once per call, after its last attempt.
+	ObserveCall(CallMetrics)
+	// ObserveAttempt is called after every round trip, including the ones
+	// that are retried.
+	ObserveAttempt(AttemptMetrics)
+	// ObserveBatch is called when a batch is submitted and when one is
+	// fetched and found finished.
+	ObserveBatch(BatchMetrics)
+}
+
+// CallMetrics describes a call, retries included.
+type CallMetrics struct {
+	Operation string
+	Method    string
+	// StatusCode is the status of the last attempt, 0 if it got no response.
+	StatusCode int
+	Duration   time.Duration
+	Attempts   int
+
+	// ErrorType is empty on success. See ErrorType.
+	ErrorType string
+}
+
+// AttemptMetrics describes a single round trip.
+type AttemptMetrics struct {
+	Operation string
+	Method    string
+	// StatusCode is 0 when the attempt got no response.
+	StatusCode int
+	Duration   time.Duration
+
+	// Retried is true when the attempt failed and another one follows.
+	Retried bool
+}
+
+// BatchMetrics describes a batch operation.
+type BatchMetrics struct {
+	// Submitted is true when the batch has just been created, and false
+	// when WaitForBatch saw it finish.
+	Submitted bool
+
+	ID                 string
+	Status             string
+	TotalOperations    int
+	FinishedOperations int
+	ErroredOperations  int
+}
+
+// ErrorType classifies err for metrics: the Title of an APIError, falling
+// back to its status text, the status text of an HTTPError, "canceled" and
+// "timeout" for context errors and "transport" for anything else. The Type
+// of an APIError isn't used, Mailchimp sets it to the same URL for all.
+func ErrorType(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		if apiErr.Title != "" {
+			return apiErr.Title
+		}
+		return http.StatusText(apiErr.Status)
+	}
+
+	var httpErr *HTTPError
+	if errors.As(err, &httpErr) {
+		return http.StatusText(httpErr.StatusCode)
+	}
+
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	}
+	return "transport"
+}
+
+func (op *Operation) metricName() string {
+	if op.Name == "" {
+		return "request"
+	}
+	return op.Name
+}
+
+// observeCall reports op, whose last attempt got a response with the given
+// status, or 0 if it got none.
+func (api *API) observeCall(op *Operation, status int, d time.Duration, err error) {
+	if api.Metrics == nil {
+		return
+	}
+
+	api.Metrics.ObserveCall(CallMetrics{
+		Operation:  op.metricName(),
+		Method:     op.Method,
+		StatusCode: status,
+		Duration:   d,
+		Attempts:   op.Info.Attempts,
+		ErrorType:  ErrorType(err),
+	})
+}
+
+func (api *API) observeAttempt(op *Operation, status int, retried bool) {
+	if api.Metrics == nil {
+		return
+	}
+
+	api.Metrics.ObserveAttempt(AttemptMetrics{
+		Operation:  op.metricName(),
+		Method:     op.Method,
+		StatusCode: status,
+		Duration:   op.Info.Latency,
+		Retried:    retried,
+	})
+}
+
+func (api *API) observeBatch(batch *BatchOperationResponse, submitted bool) {
+	if api.Metrics == nil {
+		return
+	}
+
+	api.Metrics.ObserveBatch(BatchMetrics{
+		Submitted:          submitted,
+		ID:                 batch.ID,
+		Status:             batch.Status,
+		TotalOperations:    batch.TotalOperations,
+		FinishedOperations: batch.FinishedOperations,
+		ErroredOperations:  batch.ErroredOperations,
+	})
+}
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency
+// histograms kept by ExpvarMetrics.
+var DefaultLatencyBuckets = []float64{0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10, 30}
+
+// ExpvarMetrics publishes metrics through expvar, as a map of counters keyed
+// by operation:
+//
+//	requests, errors (keyed by error type), retries, rate_limited,
+//	latency_seconds (a histogram per operation), batches_submitted,
+//	batch_operations_submitted, batch_operations_finished and
+//	batch_operations_errored.
+type ExpvarMetrics struct {
+	requests    *expvar.Map
+	errors      *expvar.Map
+	retries     *expvar.Map
+	rateLimited *expvar.Map
+	latency     *expvar.Map
+
+	batchesSubmitted  *expvar.Int
+	batchOpsSubmitted *expvar.Int
+	batchOpsFinished  *expvar.Int
+	batchOpsErrored   *expvar.Int
+
+	buckets    []float64
+	mu         sync.Mutex
+	histograms map[string]*histogram
+}
+
+// NewExpvarMetrics publishes the metrics under name. It panics if name is
+// already published, like expvar.Publish.
+func NewExpvarMetrics(name string) *ExpvarMetrics {
+	m := &ExpvarMetrics{
+		requests:          new(expvar.Map).Init(),
+		errors:            new(expvar.Map).Init(),
+		retries:           new(expvar.Map).Init(),
+		rateLimited:       new(expvar.Map).Init(),
+		latency:           new(expvar.Map).Init(),
+		batchesSubmitted:  new(expvar.Int),
+		batchOpsSubmitted: new(expvar.Int),
+		batchOpsFinished:  new(expvar.Int),
+		batchOpsErrored:   new(expvar.Int),
+		buckets:           DefaultLatencyBuckets,
+		histograms:        make(map[string]*histogram),
+	}
+
+	root := expvar.NewMap(name)
+	root.Set("requests", m.requests)
+	root.Set("errors", m.errors)
+	root.Set("retries", m.retries)
+	root.Set("rate_limited", m.rateLimited)
+	root.Set("latency_seconds", m.latency)
+	root.Set("batches_submitted", m.batchesSubmitted)
+	root.Set("batch_operations_submitted", m.batchOpsSubmitted)
+	root.Set("batch_operations_finished", m.batchOpsFinished)
+	root.Set("batch_operations_errored", m.batchOpsErrored)
+
+	return m
+}
+
+func (m *ExpvarMetrics) ObserveCall(c CallMetrics) {
+	m.requests.Add(c.Operation, 1)
+	if c.ErrorType != "" {
+		m.errors.Add(c.ErrorType, 1)
+	}
+
+	m.mu.Lock()
+	h, ok := m.histograms[c.Operation]
+	if !ok {
+		h = &histogram{buckets: m.buckets, counts: make([]uint64, len(m.buckets))}
+		m.histograms[c.Operation] = h
+		m.latency.Set(c.Operation, h)
+	}
+	m.mu.Unlock()
+
+	h.observe(c.Duration.Seconds())
+}
+
+func (m *ExpvarMetrics) ObserveAttempt(a AttemptMetrics) {
+	if a.Retried {
+		m.retries.Add(a.Operation, 1)
+	}
+	if a.StatusCode == http.StatusTooManyRequests {
+		m.rateLimited.Add(a.Operation, 1)
+	}
+}
+
+func (m *ExpvarMetrics) ObserveBatch(b BatchMetrics) {
+	if b.Submitted {
+		m.batchesSubmitted.Add(1)
+		m.batchOpsSubmitted.Add(int64(b.TotalOperations))
+		return
+	}
+
+	m.batchOpsFinished.Add(int64(b.FinishedOperations))
+	m.batchOpsErrored.Add(int64(b.ErroredOperations))
+}
+
+// histogram is a cumulative histogram rendered as an expvar JSON object.
+type histogram struct {
+	mu      sync.Mutex
+	buckets []float64
+	counts  []uint64
+	count   uint64
+	sum     float64
+}
+
+func (h *histogram) observe(v float64) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for i, b := range h.buckets {
+		if v <= b {
+			h.counts[i]++
+		}
+	}
+	h.count++
+	h.sum += v
+}
+
+func (h *histogram) String() string {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	b := []byte(`{"count":`)
+	b = strconv.AppendUint(b, h.count, 10)
+	b = append(b, `,"sum":`...)
+	b = strconv.AppendFloat(b, h.sum, 'g', -1, 64)
+	b = append(b, `,"buckets":{`...)
+	for i, le := range h.buckets {
+		if i > 0 {
+			b = append(b, ',')
+		}
+		b = append(b, '"')
+		b = strconv.AppendFloat(b, le, 'g', -1, 64)
+		b = append(b, `":`...)
+		b = strconv.AppendUint(b, h.counts[i], 10)
+	}
+	b = append(b, "}}"...)
+	return string(b)
+}
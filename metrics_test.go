@@ -0,0 +1,203 @@
+package gochimp3
+
+import (
+	"encoding/json"
+	"errors"
+	"expvar"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedMetrics struct {
+	mu       sync.Mutex
+	calls    []CallMetrics
+	attempts []AttemptMetrics
+	batches  []BatchMetrics
+}
+
+func (m *recordedMetrics) ObserveCall(c CallMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.calls = append(m.calls, c)
+}
+
+func (m *recordedMetrics) ObserveAttempt(a AttemptMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.attempts = append(m.attempts, a)
+}
+
+func (m *recordedMetrics) ObserveBatch(b BatchMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.batches = append(m.batches, b)
+}
+
+func metricsAPI(t *testing.T, handler http.HandlerFunc) (*API, *recordedMetrics) {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	m := new(recordedMetrics)
+	api.Metrics = m
+	return api, m
+}
+
+func TestMetricsRetries(t *testing.T) {
+	calls := 0
+	api, m := metricsAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+			return
+		}
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"type":"https://mailchimp.com/developer/marketing/docs/errors/","title":"Resource Not Found","status":404}`))
+	})
+	api.Retry = testRetryPolicy()
+
+	_, err := api.GetList(t.Context(), "abc", nil)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	if assert.Len(t, m.attempts, 2) {
+		assert.Equal(t, AttemptMetrics{Operation: "GetList", Method: "GET", StatusCode: 429, Duration: m.attempts[0].Duration, Retried: true}, m.attempts[0])
+		assert.Equal(t, 404, m.attempts[1].StatusCode)
+		assert.False(t, m.attempts[1].Retried)
+	}
+
+	if assert.Len(t, m.calls, 1) {
+		c := m.calls[0]
+		assert.Equal(t, "GetList", c.Operation)
+		assert.Equal(t, 404, c.StatusCode)
+		assert.Equal(t, 2, c.Attempts)
+		assert.Equal(t, "Resource Not Found", c.ErrorType)
+		assert.GreaterOrEqual(t, c.Duration, m.attempts[0].Duration)
+	}
+}
+
+func TestMetricsTransportErrorAfterResponse(t *testing.T) {
+	api, err := NewWithOptions("apikey", WithBaseURL("http://mailchimp.invalid"), WithAPIVersion(""),
+		WithHTTPClient(&http.Client{Transport: rateLimitedThenReset()}))
+	fatalIf(t, err)
+	api.Retry = testRetryPolicy()
+	api.Retry.MaxAttempts = 2
+	m := new(recordedMetrics)
+	api.Metrics = m
+
+	_, err = api.GetList(t.Context(), "abc", nil)
+	assert.Error(t, err)
+
+	if assert.Len(t, m.attempts, 2) {
+		assert.Equal(t, 429, m.attempts[0].StatusCode)
+		assert.Equal(t, 0, m.attempts[1].StatusCode)
+	}
+	if assert.Len(t, m.calls, 1) {
+		assert.Equal(t, 0, m.calls[0].StatusCode)
+		assert.Equal(t, "transport", m.calls[0].ErrorType)
+	}
+}
+
+func TestMetricsBatches(t *testing.T) {
+	gets := 0
+	api, m := metricsAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		status := "pending"
+		if r.Method == "GET" {
+			gets++
+			status = "started"
+			if gets > 1 {
+				status = "finished"
+			}
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"id":                  "b1",
+			"status":              status,
+			"total_operations":    3,
+			"finished_operations": 3,
+			"errored_operations":  1,
+		})
+	})
+
+	_, err := api.CreateBatchOperation(t.Context(), &BatchOperationCreationRequest{
+		Operations: []BatchOperation{{Method: "GET", Path: "/lists"}},
+	})
+	fatalIf(t, err)
+
+	_, err = api.WaitForBatch(t.Context(), "b1", &WaitOptions{MinInterval: time.Millisecond})
+	fatalIf(t, err)
+
+	// Fetching the finished batch again doesn't count it twice.
+	_, err = api.GetBatchOperation(t.Context(), "b1", nil)
+	fatalIf(t, err)
+	_, err = api.GetBatchOperation(t.Context(), "b1", nil)
+	fatalIf(t, err)
+
+	if assert.Len(t, m.batches, 2) {
+		assert.True(t, m.batches[0].Submitted)
+		assert.Equal(t, 3, m.batches[0].TotalOperations)
+		assert.False(t, m.batches[1].Submitted)
+		assert.Equal(t, "finished", m.batches[1].Status)
+		assert.Equal(t, 1, m.batches[1].ErroredOperations)
+	}
+}
+
+func TestMetricsBatchFinishedOnFirstPoll(t *testing.T) {
+	api, m := metricsAPI(t, func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(`{"id":"b1","status":"finished","total_operations":2,"finished_operations":2,"errored_operations":1}`))
+	})
+
+	batch, err := api.WaitForBatch(t.Context(), "b1", nil)
+	fatalIf(t, err)
+	assert.Equal(t, "finished", batch.Status)
+
+	if assert.Len(t, m.batches, 1) {
+		assert.False(t, m.batches[0].Submitted)
+		assert.Equal(t, 2, m.batches[0].FinishedOperations)
+		assert.Equal(t, 1, m.batches[0].ErroredOperations)
+	}
+}
+
+func TestErrorType(t *testing.T) {
+	assert.Equal(t, "", ErrorType(nil))
+	assert.Equal(t, "Member Exists", ErrorType(&APIError{Type: "https://mailchimp.com/developer/marketing/docs/errors/", Title: "Member Exists"}))
+	assert.Equal(t, "Invalid Resource", ErrorType(&APIError{Type: "https://mailchimp.com/developer/marketing/docs/errors/", Title: "Invalid Resource"}))
+	assert.Equal(t, "Not Found", ErrorType(&APIError{Status: 404}))
+	assert.Equal(t, "Bad Gateway", ErrorType(&HTTPError{StatusCode: 502}))
+	assert.Equal(t, "transport", ErrorType(errors.New("connection reset")))
+}
+
+func TestExpvarMetrics(t *testing.T) {
+	m := NewExpvarMetrics("gochimp3_test")
+	m.ObserveCall(CallMetrics{Operation: "GetList", Duration: 200 * time.Millisecond})
+	m.ObserveCall(CallMetrics{Operation: "GetList", Duration: 2 * time.Second, ErrorType: "Resource Not Found"})
+	m.ObserveAttempt(AttemptMetrics{Operation: "GetList", StatusCode: 429, Retried: true})
+	m.ObserveBatch(BatchMetrics{Submitted: true, TotalOperations: 5})
+
+	var out struct {
+		Requests       map[string]int `json:"requests"`
+		Errors         map[string]int `json:"errors"`
+		Retries        map[string]int `json:"retries"`
+		RateLimited    map[string]int `json:"rate_limited"`
+		BatchOps       int            `json:"batch_operations_submitted"`
+		LatencySeconds map[string]struct {
+			Count   int            `json:"count"`
+			Buckets map[string]int `json:"buckets"`
+		} `json:"latency_seconds"`
+	}
+	fatalIf(t, json.Unmarshal([]byte(expvar.Get("gochimp3_test").String()), &out))
+
+	assert.Equal(t, 2, out.Requests["GetList"])
+	assert.Equal(t, 1, out.Errors["Resource Not Found"])
+	assert.Equal(t, 1, out.Retries["GetList"])
+	assert.Equal(t, 1, out.RateLimited["GetList"])
+	assert.Equal(t, 5, out.BatchOps)
+	assert.Equal(t, 2, out.LatencySeconds["GetList"].Count)
+	assert.Equal(t, 1, out.LatencySeconds["GetList"].Buckets["0.25"])
+	assert.Equal(t, 2, out.LatencySeconds["GetList"].Buckets["2.5"])
+}
@@ -0,0 +1,129 @@
+package gochimp3
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+)
+
+// ErrInvalidPathArg is returned for calls with an empty, "." or ".." ID,
+// which would make the path point to another endpoint.
+var ErrInvalidPathArg = errors.New("gochimp3: invalid ID in path")
+
+// Operation describes a single logical call to the Mailchimp API, as seen by
+// middleware. Middleware may change any of its fields before calling the
+// next handler; they are only turned into an HTTP request at the end of the
+// chain.
+type Operation struct {
+	// Name identifies the method that made the call, e.g.
+	// "ListAddOrUpdateMember" for methods on API or
+	// "ListResponse.GetMembers" for methods on other types.
+	Name   string
+	Method string
+
+	// PathTemplate is the endpoint with %s placeholders, e.g.
+	// "/lists/%s/members/%s", and PathArgs the unescaped values filling
+	// them in.
+	PathTemplate string
+	PathArgs     []string
+
+	Params QueryParams
+	Body   interface{}
+
+	// Response is what a successful response is decoded into. It may be nil.
+	Response interface{}
+
+	// Header holds extra headers sent with every attempt.
+	Header http.Header
+
+	// Info describes the HTTP exchange once the next handler has returned.
+	Info *ResponseInfo
+}
+
+// Path returns the endpoint the operation targets, relative to the API's
+// endpoint. Each of PathArgs is escaped as a single path segment, so that
+// IDs containing "/", "?" or "#" can't reach another resource. Calls with
+// empty, "." or ".." IDs fail with ErrInvalidPathArg before being sent.
+func (op *Operation) Path() string {
+	if len(op.PathArgs) == 0 {
+		return op.PathTemplate
+	}
+
+	args := make([]interface{}, len(op.PathArgs))
+	for i, a := range op.PathArgs {
+		args[i] = url.PathEscape(a)
+	}
+	return fmt.Sprintf(op.PathTemplate, args...)
+}
+
+// checkPathArgs returns ErrInvalidPathArg if one of PathArgs, although
+// escaped, would still change the path's meaning.
+func (op *Operation) checkPathArgs() error {
+	for _, a := range op.PathArgs {
+		if a == "" || a == "." || a == ".." {
+			return fmt.Errorf("%w: %q", ErrInvalidPathArg, a)
+		}
+	}
+	return nil
+}
+
+// Handler executes an Operation.
+type Handler func(ctx context.Context, op *Operation) error
+
+// Middleware wraps a Handler to act on every operation, e.g.
+//
+//	func addHeader(next gochimp3.Handler) gochimp3.Handler {
+//		return func(ctx context.Context, op *gochimp3.Operation) error {
+//			op.Header.Set("X-Trace", "abc")
+//			return next(ctx, op)
+//		}
+//	}
+type Middleware func(next Handler) Handler
+
+func newOperation(name, method, template string, args ...string) *Operation {
+	return &Operation{
+		Name:         name,
+		Method:       method,
+		PathTemplate: template,
+		PathArgs:     args,
+	}
+}
+
+// do validates body, runs op through the middleware chain and then sends
+// it.
+func (api *API) do(ctx context.Context, op *Operation, params QueryParams, body, response interface{}) error {
+	op.Params = params
+	op.Body = body
+	op.Response = response
+	op.Header = make(http.Header)
+	op.Info = responseInfoFrom(ctx)
+
+	if err := op.checkPathArgs(); err != nil {
+		return err
+	}
+
+	if !api.DisableValidation {
+		if err := validate(op.Method, body); err != nil {
+			return err
+		}
+	}
+
+	h := api.execute
+	for i := len(api.Middleware) - 1; i >= 0; i-- {
+		h = api.Middleware[i](h)
+	}
+
+	return h(ctx, op)
+}
+
+// doOk runs op ignoring the response body and returns true if HTTP status
+// code is 2xx.
+func (api *API) doOk(ctx context.Context, op *Operation) (bool, error) {
+	err := api.do(ctx, op, nil, nil, nil)
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
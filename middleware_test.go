@@ -0,0 +1,149 @@
+package gochimp3
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMiddlewareSeesOperation(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/lists/abc/members/def", r.URL.Path)
+		assert.Equal(t, "mw", r.Header.Get("X-Test"))
+		w.Header().Set("X-Request-Id", "req-1")
+		w.Write([]byte(`{"id":"def","email_address":"a@b.c"}`))
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	var order []string
+	var seen *Operation
+	api.Middleware = []Middleware{
+		func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) error {
+				order = append(order, "outer")
+				return next(ctx, op)
+			}
+		},
+		func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) error {
+				order = append(order, "inner")
+				op.Header.Set("X-Test", "mw")
+				err := next(ctx, op)
+				seen = op
+				return err
+			}
+		},
+	}
+
+	body := &MemberRequest{EmailAddress: "a@b.c", Status: "subscribed"}
+	member, err := api.NewListResponse("abc").AddOrUpdateMember(t.Context(), "def", body)
+	fatalIf(t, err)
+	assert.Equal(t, "def", member.ID)
+
+	assert.Equal(t, []string{"outer", "inner"}, order)
+	assert.Equal(t, "ListResponse.AddOrUpdateMember", seen.Name)
+	assert.Equal(t, "PUT", seen.Method)
+	assert.Equal(t, single_member_path, seen.PathTemplate)
+	assert.Equal(t, []string{"abc", "def"}, seen.PathArgs)
+	assert.Equal(t, body, seen.Body)
+	assert.Equal(t, member, seen.Response)
+	assert.Equal(t, http.StatusOK, seen.Info.StatusCode)
+	assert.Equal(t, "req-1", seen.Info.RequestID)
+
+	_, err = api.ListAddOrUpdateMember(t.Context(), "abc", "def", body)
+	fatalIf(t, err)
+	assert.Equal(t, "ListAddOrUpdateMember", seen.Name)
+}
+
+func TestMiddlewareShortCircuit(t *testing.T) {
+	api, err := NewWithOptions("apikey", WithBaseURL("http://localhost:1"), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	api.Middleware = []Middleware{
+		func(next Handler) Handler {
+			return func(ctx context.Context, op *Operation) error {
+				params, ok := op.Params.(*ListQueryParams)
+				assert.True(t, ok)
+				assert.Equal(t, 5, params.Count)
+
+				op.Response.(*ListOfLists).TotalItems = 42
+				return nil
+			}
+		},
+	}
+
+	params := &ListQueryParams{}
+	params.Count = 5
+	lists, err := api.GetLists(t.Context(), params)
+	fatalIf(t, err)
+	assert.Equal(t, 42, lists.TotalItems)
+}
+
+func TestOperationPathEscapes(t *testing.T) {
+	op := newOperation("", "GET", single_member_path, "abc", "../x?y=1#z")
+	assert.Equal(t, "/lists/abc/members/..%2Fx%3Fy=1%23z", op.Path())
+
+	op = newOperation("", "GET", lists_path)
+	assert.Equal(t, "/lists", op.Path())
+}
+
+func TestHostileIDs(t *testing.T) {
+	var paths []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths = append(paths, r.URL.EscapedPath())
+		assert.Empty(t, r.URL.RawQuery)
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	ctx := t.Context()
+	list := api.NewListResponse("l/1")
+	mem := api.MemberForApiCalls("l?1", "a@b.c")
+	mem.ID = "m#1"
+
+	_, err = api.GetList(ctx, "../campaigns", nil)
+	fatalIf(t, err)
+	_, err = list.GetGrowthHistoryForMonth(ctx, "2024/01", nil)
+	fatalIf(t, err)
+	_, err = list.GetSegment(ctx, "s?count=1", nil)
+	fatalIf(t, err)
+	_, err = list.GetWebHook(ctx, "w 1")
+	fatalIf(t, err)
+	_, err = mem.GetNote(ctx, "n/2", nil)
+	fatalIf(t, err)
+	_, err = api.GetCampaign(ctx, "c%2F1", nil)
+	fatalIf(t, err)
+	_, err = api.GetTemplate(ctx, "t/../1", nil)
+	fatalIf(t, err)
+
+	assert.Equal(t, []string{
+		"/lists/..%2Fcampaigns",
+		"/lists/l%2F1/growth-history/2024%2F01",
+		"/lists/l%2F1/segments/s%3Fcount=1",
+		"/lists/l%2F1/webhooks/w%201",
+		"/lists/l%3F1/members/m%231/notes/n%2F2",
+		"/campaigns/c%252F1",
+		"/templates/t%2F..%2F1",
+	}, paths)
+
+	_, err = api.GetList(ctx, "..", nil)
+	assert.ErrorIs(t, err, ErrInvalidPathArg)
+	_, err = api.GetList(ctx, ".", nil)
+	assert.ErrorIs(t, err, ErrInvalidPathArg)
+	_, err = api.GetList(ctx, "", nil)
+	assert.ErrorIs(t, err, ErrInvalidPathArg)
+	_, err = list.GetMember(ctx, "..", nil)
+	assert.ErrorIs(t, err, ErrInvalidPathArg)
+	_, err = mem.GetNote(ctx, ".", nil)
+	assert.ErrorIs(t, err, ErrInvalidPathArg)
+	assert.Len(t, paths, 7)
+}
@@ -0,0 +1,186 @@
+package gochimp3
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// OAuthHost is the server handling Mailchimp's OAuth2 flow.
+const OAuthHost = "https://login.mailchimp.com"
+
+const (
+	oauth_authorize_path = "/oauth2/authorize"
+	oauth_token_path     = "/oauth2/token"
+	oauth_metadata_path  = "/oauth2/metadata"
+)
+
+// OAuthConfig holds the details of a registered Mailchimp app, used to
+// connect customer accounts through the authorization code flow.
+type OAuthConfig struct {
+	ClientID     string
+	ClientSecret string
+	RedirectURL  string
+
+	// Host is the OAuth server. Defaults to OAuthHost.
+	Host string
+	// Client is used for the token exchange. Defaults to http.DefaultClient.
+	Client *http.Client
+}
+
+// OAuthToken is the result of exchanging an authorization code. Mailchimp
+// tokens don't expire, so ExpiresIn is normally 0.
+type OAuthToken struct {
+	AccessToken string `json:"access_token"`
+	ExpiresIn   int    `json:"expires_in"`
+}
+
+// OAuthMetadata describes the account an access token belongs to.
+type OAuthMetadata struct {
+	DC          string `json:"dc"`
+	Role        string `json:"role"`
+	AccountName string `json:"accountname"`
+	UserID      int    `json:"user_id"`
+	LoginURL    string `json:"login_url"`
+	APIEndpoint string `json:"api_endpoint"`
+}
+
+// OAuthError is returned by the OAuth server when a step of the flow fails.
+type OAuthError struct {
+	StatusCode  int    `json:"-"`
+	Code        string `json:"error"`
+	Description string `json:"error_description"`
+}
+
+func (err *OAuthError) Error() string {
+	return fmt.Sprintf("%d : %s : %s", err.StatusCode, err.Code, err.Description)
+}
+
+func (c *OAuthConfig) host() string {
+	if c.Host == "" {
+		return OAuthHost
+	}
+	return strings.TrimSuffix(c.Host, "/")
+}
+
+func (c *OAuthConfig) client() *http.Client {
+	if c.Client == nil {
+		return http.DefaultClient
+	}
+	return c.Client
+}
+
+// AuthCodeURL returns the URL to send the user to so they can grant access to
+// their account. state is passed back to the redirect URL unchanged.
+func (c *OAuthConfig) AuthCodeURL(state string) string {
+	v := url.Values{}
+	v.Set("response_type", "code")
+	v.Set("client_id", c.ClientID)
+	if c.RedirectURL != "" {
+		v.Set("redirect_uri", c.RedirectURL)
+	}
+	if state != "" {
+		v.Set("state", state)
+	}
+
+	return c.host() + oauth_authorize_path + "?" + v.Encode()
+}
+
+// Exchange trades the code received on the redirect URL for an access token.
+func (c *OAuthConfig) Exchange(ctx context.Context, code string) (*OAuthToken, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("client_id", c.ClientID)
+	form.Set("client_secret", c.ClientSecret)
+	form.Set("redirect_uri", c.RedirectURL)
+	form.Set("code", code)
+
+	req, err := http.NewRequestWithContext(ctx, "POST", c.host()+oauth_token_path, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	token := new(OAuthToken)
+	return token, doOAuth(c.client(), req, token)
+}
+
+// Metadata looks up the account and datacenter the access token belongs to.
+func (c *OAuthConfig) Metadata(ctx context.Context, accessToken string) (*OAuthMetadata, error) {
+	return fetchOAuthMetadata(ctx, c.client(), c.host(), accessToken)
+}
+
+// NewWithAccessToken creates an API authenticating with an OAuth2 access
+// token. Unless WithBaseURL is given, the account's API endpoint is
+// discovered through the OAuth metadata endpoint.
+func NewWithAccessToken(ctx context.Context, accessToken string, opts ...Option) (*API, error) {
+	o := newOptions(opts)
+
+	if o.baseURL == "" {
+		meta, err := fetchOAuthMetadata(ctx, o.client, strings.TrimSuffix(o.oauthHost, "/"), accessToken)
+		if err != nil {
+			return nil, err
+		}
+
+		o.baseURL = meta.APIEndpoint
+		if o.baseURL == "" && meta.DC != "" {
+			o.baseURL = "https://" + fmt.Sprintf(URIFormat, meta.DC)
+		}
+	}
+
+	api, err := o.build()
+	if err != nil {
+		return nil, err
+	}
+
+	api.AccessToken = accessToken
+	return api, nil
+}
+
+func fetchOAuthMetadata(ctx context.Context, client *http.Client, host, accessToken string) (*OAuthMetadata, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", host+oauth_metadata_path, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Authorization", "OAuth "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	meta := new(OAuthMetadata)
+	if err := doOAuth(client, req, meta); err != nil {
+		return nil, err
+	}
+
+	if meta.APIEndpoint == "" && meta.DC == "" {
+		return nil, fmt.Errorf("OAuth metadata has no datacenter")
+	}
+
+	return meta, nil
+}
+
+func doOAuth(client *http.Client, req *http.Request, response interface{}) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		oauthErr := &OAuthError{StatusCode: resp.StatusCode}
+		if json.Unmarshal(data, oauthErr) != nil || oauthErr.Code == "" {
+			oauthErr.Code = http.StatusText(resp.StatusCode)
+			oauthErr.Description = strings.TrimSpace(string(data))
+		}
+		return oauthErr
+	}
+
+	return json.Unmarshal(data, response)
+}
@@ -0,0 +1,91 @@
+package gochimp3
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func oauthTestServer(t *testing.T) *httptest.Server {
+	var srv *httptest.Server
+	mux := http.NewServeMux()
+	mux.HandleFunc("/oauth2/metadata", func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Authorization") != "OAuth token" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_token", "error_description": "bad token"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]interface{}{
+			"dc":           "us6",
+			"accountname":  "Acme",
+			"api_endpoint": srv.URL,
+		})
+	})
+	mux.HandleFunc("/oauth2/token", func(w http.ResponseWriter, r *http.Request) {
+		fatalIf(t, r.ParseForm())
+		assert.Equal(t, "authorization_code", r.PostForm.Get("grant_type"))
+		assert.Equal(t, "id", r.PostForm.Get("client_id"))
+		assert.Equal(t, "secret", r.PostForm.Get("client_secret"))
+		assert.Equal(t, "the-code", r.PostForm.Get("code"))
+		w.Write([]byte(`{"access_token": "token", "expires_in": 0, "scope": null}`))
+	})
+	mux.HandleFunc("/3.0/ping", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		w.Write([]byte(`{"health_status": "Everything's Chimpy!"}`))
+	})
+
+	srv = httptest.NewServer(mux)
+	t.Cleanup(srv.Close)
+	return srv
+}
+
+func TestOAuthFlow(t *testing.T) {
+	srv := oauthTestServer(t)
+
+	config := &OAuthConfig{
+		ClientID:     "id",
+		ClientSecret: "secret",
+		RedirectURL:  "https://example.com/callback",
+		Host:         srv.URL,
+	}
+
+	u, err := url.Parse(config.AuthCodeURL("xyz"))
+	fatalIf(t, err)
+	assert.Equal(t, "/oauth2/authorize", u.Path)
+	assert.Equal(t, "code", u.Query().Get("response_type"))
+	assert.Equal(t, "id", u.Query().Get("client_id"))
+	assert.Equal(t, "https://example.com/callback", u.Query().Get("redirect_uri"))
+	assert.Equal(t, "xyz", u.Query().Get("state"))
+
+	token, err := config.Exchange(t.Context(), "the-code")
+	fatalIf(t, err)
+	assert.Equal(t, "token", token.AccessToken)
+
+	meta, err := config.Metadata(t.Context(), token.AccessToken)
+	fatalIf(t, err)
+	assert.Equal(t, "us6", meta.DC)
+	assert.Equal(t, "Acme", meta.AccountName)
+}
+
+func TestNewWithAccessToken(t *testing.T) {
+	srv := oauthTestServer(t)
+
+	api, err := NewWithAccessToken(t.Context(), "token", WithOAuthHost(srv.URL))
+	fatalIf(t, err)
+	assert.Equal(t, srv.URL+Version, api.Endpoint())
+
+	response := make(map[string]string)
+	err = api.request(t.Context(), "GET", "/ping", nil, nil, &response)
+	fatalIf(t, err)
+	assert.Equal(t, "Everything's Chimpy!", response["health_status"])
+
+	_, err = NewWithAccessToken(t.Context(), "wrong", WithOAuthHost(srv.URL))
+	oauthErr, ok := err.(*OAuthError)
+	assert.True(t, ok)
+	assert.Equal(t, http.StatusUnauthorized, oauthErr.StatusCode)
+	assert.Equal(t, "invalid_token", oauthErr.Code)
+}
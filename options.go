@@ -0,0 +1,137 @@
+package gochimp3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// ErrInvalidAPIKey is returned by NewWithOptions when the datacenter can't be
+// taken from the key, e.g. "0123456789abcdef" instead of
+// "0123456789abcdef-us6".
+var ErrInvalidAPIKey = errors.New("invalid API key: expected <key>-<datacenter>")
+
+var apiKeyRegex = regexp.MustCompile(`^[^-]+-(\w+)$`)
+
+// Option configures an API created by NewWithOptions.
+type Option func(*options)
+
+type options struct {
+	baseURL   string
+	version   string
+	user      string
+	userAgent string
+	oauthHost string
+	client    *http.Client
+}
+
+func newOptions(opts []Option) *options {
+	o := &options{
+		version:   Version,
+		user:      "gochimp3",
+		oauthHost: OAuthHost,
+		client:    http.DefaultClient,
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	if o.client == nil {
+		o.client = http.DefaultClient
+	}
+	return o
+}
+
+// WithBaseURL overrides the scheme and host the API talks to, which are
+// otherwise derived from the key's datacenter. The API version path is
+// still appended.
+func WithBaseURL(baseURL string) Option {
+	return func(o *options) {
+		o.baseURL = baseURL
+	}
+}
+
+// WithHTTPClient sets the client used for every request. Defaults to
+// http.DefaultClient.
+func WithHTTPClient(client *http.Client) Option {
+	return func(o *options) {
+		o.client = client
+	}
+}
+
+// WithUserAgent sets the User-Agent header sent with every request.
+func WithUserAgent(userAgent string) Option {
+	return func(o *options) {
+		o.userAgent = userAgent
+	}
+}
+
+// WithUser sets the basic auth user name. Mailchimp ignores it, but it shows
+// up in the account's API logs. Defaults to "gochimp3".
+func WithUser(user string) Option {
+	return func(o *options) {
+		o.user = user
+	}
+}
+
+// WithAPIVersion sets the version path appended to the base URL. Defaults to
+// Version; pass "" to use the base URL as is.
+func WithAPIVersion(version string) Option {
+	return func(o *options) {
+		o.version = version
+	}
+}
+
+// WithOAuthHost overrides the Mailchimp OAuth server queried by
+// NewWithAccessToken for the account's datacenter. Defaults to OAuthHost.
+func WithOAuthHost(host string) Option {
+	return func(o *options) {
+		o.oauthHost = host
+	}
+}
+
+// NewWithOptions creates an API, validating the key and any overrides.
+func NewWithOptions(apiKey string, opts ...Option) (*API, error) {
+	o := newOptions(opts)
+
+	if o.baseURL == "" {
+		m := apiKeyRegex.FindStringSubmatch(apiKey)
+		if m == nil {
+			return nil, ErrInvalidAPIKey
+		}
+		o.baseURL = "https://" + fmt.Sprintf(URIFormat, m[1])
+	}
+
+	api, err := o.build()
+	if err != nil {
+		return nil, err
+	}
+
+	api.Key = apiKey
+	return api, nil
+}
+
+func (o *options) build() (*API, error) {
+	u, err := url.Parse(o.baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme and host are required", o.baseURL)
+	}
+
+	return &API{
+		User:      o.user,
+		UserAgent: o.userAgent,
+		Client:    o.client,
+		Limiter:   NewLimiter(MaxConnections, 0, 0),
+		endpoint:  strings.TrimSuffix(u.String(), "/") + o.version,
+	}, nil
+}
+
+// Endpoint returns the URL every request path is appended to.
+func (api *API) Endpoint() string {
+	return api.endpoint
+}
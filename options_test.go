@@ -0,0 +1,49 @@
+package gochimp3
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewWithOptionsDatacenter(t *testing.T) {
+	api, err := NewWithOptions("0123456789abcdef-us6")
+	fatalIf(t, err)
+	assert.Equal(t, "https://us6.api.mailchimp.com/3.0", api.Endpoint())
+	assert.Equal(t, "gochimp3", api.User)
+	assert.Equal(t, http.DefaultClient, api.Client)
+
+	for _, key := range []string{"", "0123456789abcdef", "0123456789abcdef-", "-us6"} {
+		_, err := NewWithOptions(key)
+		assert.ErrorIs(t, err, ErrInvalidAPIKey, key)
+	}
+}
+
+func TestNewWithOptionsOverrides(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		user, pass, ok := r.BasicAuth()
+		assert.True(t, ok)
+		assert.Equal(t, "me", user)
+		assert.Equal(t, "apikey", pass)
+		assert.Equal(t, "my-service/1.0", r.Header.Get("User-Agent"))
+	}
+
+	client := &http.Client{}
+	api, err := NewWithOptions("apikey",
+		WithBaseURL(testServer),
+		WithAPIVersion(""),
+		WithHTTPClient(client),
+		WithUser("me"),
+		WithUserAgent("my-service/1.0"),
+	)
+	fatalIf(t, err)
+	assert.Equal(t, testServer, api.Endpoint())
+	assert.Equal(t, client, api.Client)
+
+	_, err = api.requestOk(t.Context(), "GET", "/somewhere")
+	fatalIf(t, err)
+
+	_, err = NewWithOptions("apikey", WithBaseURL("localhost:9999"))
+	assert.NotNil(t, err)
+}
@@ -0,0 +1,103 @@
+package gochimp3
+
+import (
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// encodeQuery turns a params struct into query parameters using the `url`
+// struct tags of its fields. Zero values are left out, so they never
+// override a value set on an embedded struct. Slices are joined with commas
+// and times are formatted as ISO 8601. Fields without a tag are ignored.
+func encodeQuery(v interface{}) map[string]string {
+	m := make(map[string]string)
+	encodeStruct(reflect.ValueOf(v), m)
+	return m
+}
+
+func encodeStruct(v reflect.Value, m map[string]string) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Struct {
+		return
+	}
+
+	t := v.Type()
+
+	// Embedded structs first, so that the outer fields win.
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if f.Anonymous && f.Tag.Get("url") == "" {
+			encodeStruct(v.Field(i), m)
+		}
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		name := f.Tag.Get("url")
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if s, ok := encodeValue(v.Field(i)); ok {
+			m[name] = s
+		}
+	}
+}
+
+// encodeValue formats a single field, reporting false for zero values.
+func encodeValue(v reflect.Value) (string, bool) {
+	if v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return "", false
+		}
+		// A non-nil *bool is sent even when false, which allows filtering on
+		// false.
+		if v.Elem().Kind() == reflect.Bool {
+			return strconv.FormatBool(v.Elem().Bool()), true
+		}
+		return encodeValue(v.Elem())
+	}
+
+	if v.Type() == timeType {
+		if !v.CanInterface() {
+			return "", false
+		}
+		t := v.Interface().(time.Time)
+		if t.IsZero() {
+			return "", false
+		}
+		return t.Format(time.RFC3339), true
+	}
+
+	switch v.Kind() {
+	case reflect.String:
+		return v.String(), v.Len() > 0
+	case reflect.Bool:
+		return "true", v.Bool()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(v.Int(), 10), v.Int() != 0
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(v.Uint(), 10), v.Uint() != 0
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(v.Float(), 'f', -1, 64), v.Float() != 0
+	case reflect.Slice, reflect.Array:
+		parts := make([]string, 0, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			if s, ok := encodeValue(v.Index(i)); ok {
+				parts = append(parts, s)
+			}
+		}
+		return strings.Join(parts, ","), len(parts) > 0
+	}
+
+	return "", false
+}
@@ -0,0 +1,331 @@
+package gochimp3
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueryParamsEncoding(t *testing.T) {
+	basic := BasicQueryParams{
+		Status:        "subscribed",
+		SortField:     "last_changed",
+		SortDirection: "DESC",
+		Fields:        []string{"id", "email_address"},
+		ExcludeFields: []string{"_links"},
+	}
+	basicWant := map[string]string{
+		"status":         "subscribed",
+		"sort_field":     "last_changed",
+		"sort_dir":       "DESC",
+		"fields":         "id,email_address",
+		"exclude_fields": "_links",
+	}
+	extended := ExtendedQueryParams{BasicQueryParams: basic, Count: 50, Offset: 100}
+	day := func(d int) time.Time { return time.Date(2020, 1, d, 0, 0, 0, 0, time.UTC) }
+
+	with := func(extra map[string]string) map[string]string {
+		m := map[string]string{"count": "50", "offset": "100"}
+		for k, v := range basicWant {
+			m[k] = v
+		}
+		for k, v := range extra {
+			m[k] = v
+		}
+		return m
+	}
+
+	tests := []struct {
+		name   string
+		params QueryParams
+		want   map[string]string
+	}{
+		{"empty", &ExtendedQueryParams{}, map[string]string{}},
+		{"basic", &basic, basicWant},
+		{"extended", &extended, with(nil)},
+		{
+			"lists",
+			&ListQueryParams{
+				ExtendedQueryParams:    extended,
+				BeforeDateCreated:      time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				SinceDateCreated:       time.Date(2019, 1, 1, 0, 0, 0, 0, time.UTC),
+				BeforeCampaignLastSent: time.Date(2020, 2, 1, 0, 0, 0, 0, time.UTC),
+				SinceCampaignLastSent:  time.Date(2019, 2, 1, 0, 0, 0, 0, time.UTC),
+				Email:                  "a@example.com",
+			},
+			with(map[string]string{
+				"before_date_created":       "2020-01-01T00:00:00Z",
+				"since_date_created":        "2019-01-01T00:00:00Z",
+				"before_campaign_last_sent": "2020-02-01T00:00:00Z",
+				"since_campaign_last_sent":  "2019-02-01T00:00:00Z",
+				"email":                     "a@example.com",
+			}),
+		},
+		{
+			"members",
+			&ListGetMembersParams{
+				ExtendedQueryParams: extended,
+				EmailType:           "html",
+				UnsubscribedSince:   day(1),
+				SinceTimestampOpt:   day(2),
+				BeforeTimestampOpt:  day(3),
+				SinceLastChanged:    day(4),
+				BeforeLastChanged:   day(5),
+				SinceLastCampaign:   day(6),
+				UniqueEmailID:       "g",
+				VIPOnly:             true,
+				InterestCategoryID:  "h",
+				InterestIDs:         []string{"i", "j"},
+				InterestMatch:       "any",
+			},
+			with(map[string]string{
+				"email_type":           "html",
+				"unsubscribed_since":   "2020-01-01T00:00:00Z",
+				"since_timestamp_opt":  "2020-01-02T00:00:00Z",
+				"before_timestamp_opt": "2020-01-03T00:00:00Z",
+				"since_last_changed":   "2020-01-04T00:00:00Z",
+				"before_last_changed":  "2020-01-05T00:00:00Z",
+				"since_last_campaign":  "2020-01-06T00:00:00Z",
+				"unique_email_id":      "g",
+				"vip_only":             "true",
+				"interest_category_id": "h",
+				"interest_ids":         "i,j",
+				"interest_match":       "any",
+			}),
+		},
+		{
+			"merge fields",
+			&MergeFieldsParams{ExtendedQueryParams: extended, Type: "text", Required: true},
+			with(map[string]string{"type": "text", "required": "true"}),
+		},
+		{
+			"merge field",
+			&MergeFieldParams{BasicQueryParams: basic, MergeID: "3"},
+			basicWant,
+		},
+		{
+			"interest categories",
+			&InterestCategoriesQueryParams{ExtendedQueryParams: extended, Type: "checkboxes"},
+			with(map[string]string{"type": "checkboxes"}),
+		},
+		{
+			"campaigns",
+			&CampaignQueryParams{
+				ExtendedQueryParams: extended,
+				Type:                CAMPAIGN_TYPE_REGULAR,
+				BeforeSendTime:      day(1),
+				SinceSendTime:       day(2),
+				BeforeCreateTime:    day(3),
+				SinceCreateTime:     day(4),
+				ListId:              "e",
+				FolderId:            "f",
+				SortDir:             "ASC",
+			},
+			with(map[string]string{
+				"type":               "regular",
+				"before_send_time":   "2020-01-01T00:00:00Z",
+				"since_send_time":    "2020-01-02T00:00:00Z",
+				"before_create_time": "2020-01-03T00:00:00Z",
+				"since_create_time":  "2020-01-04T00:00:00Z",
+				"list_id":            "e",
+				"folder_id":          "f",
+				"sort_dir":           "ASC",
+			}),
+		},
+		{
+			"segments",
+			&SegmentQueryParams{
+				ExtendedQueryParams: extended,
+				Type:                "static",
+				SinceCreatedAt:      day(1),
+				BeforeCreatedAt:     day(2),
+				SinceUpdatedAt:      day(3),
+				BeforeUpdatedAt:     day(4),
+			},
+			with(map[string]string{
+				"type":              "static",
+				"since_created_at":  "2020-01-01T00:00:00Z",
+				"before_created_at": "2020-01-02T00:00:00Z",
+				"since_updated_at":  "2020-01-03T00:00:00Z",
+				"before_updated_at": "2020-01-04T00:00:00Z",
+			}),
+		},
+		{
+			"templates",
+			&TemplateQueryParams{
+				ExtendedQueryParams: extended,
+				CreatedBy:           "a",
+				SinceCreatedAt:      day(2),
+				BeforeCreatedAt:     day(3),
+				Type:                "user",
+				FolderId:            "d",
+			},
+			with(map[string]string{
+				"created_by":        "a",
+				"since_created_at":  "2020-01-02T00:00:00Z",
+				"before_created_at": "2020-01-03T00:00:00Z",
+				"type":              "user",
+				"folder_id":         "d",
+			}),
+		},
+		{
+			"search members",
+			&SearchMembersQueryParams{BasicQueryParams: basic, Query: "smith", listID: "abc"},
+			map[string]string{
+				"status":         "subscribed",
+				"sort_field":     "last_changed",
+				"sort_dir":       "DESC",
+				"fields":         "id,email_address",
+				"exclude_fields": "_links",
+				"query":          "smith",
+				"list_id":        "abc",
+			},
+		},
+		{"campaign folders", &CampaignFolderQueryParams{extended}, with(nil)},
+		{"template folders", &TemplateFolderQueryParams{extended}, with(nil)},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.params.Params())
+		})
+	}
+}
+
+func TestCampaignQueryParamsKeepsEmbeddedValues(t *testing.T) {
+	params := CampaignQueryParams{}
+	params.BasicQueryParams.Status = "sent"
+	params.BasicQueryParams.SortField = "send_time"
+	params.SortDirection = "DESC"
+
+	m := params.Params()
+	assert.Equal(t, "sent", m["status"])
+	assert.Equal(t, "send_time", m["sort_field"])
+	assert.Equal(t, "DESC", m["sort_dir"])
+
+	// The deprecated fields still work, and win when both are set.
+	params = CampaignQueryParams{Status: "save", SortField: "create_time", SortDir: "ASC"}
+	assert.Equal(t, map[string]string{"status": "save", "sort_field": "create_time", "sort_dir": "ASC"}, params.Params())
+
+	params.BasicQueryParams = BasicQueryParams{Status: "sent", SortField: "send_time", SortDirection: "DESC"}
+	assert.Equal(t, map[string]string{"status": "save", "sort_field": "create_time", "sort_dir": "ASC"}, params.Params())
+}
+
+func TestMemberFiltersReachTheWire(t *testing.T) {
+	var query url.Values
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/lists/abc/members", r.URL.Path)
+		query = r.URL.Query()
+		w.Write([]byte(`{"members": [], "total_items": 0}`))
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	params := &ListGetMembersParams{
+		SinceLastChanged: time.Date(2020, 1, 1, 0, 0, 0, 0, time.FixedZone("", 3600)),
+		UniqueEmailID:    "xyz",
+	}
+	_, err = api.ListGetMembers(t.Context(), "abc", params)
+	fatalIf(t, err)
+
+	assert.Equal(t, url.Values{
+		"since_last_changed": {"2020-01-01T00:00:00+01:00"},
+		"unique_email_id":    {"xyz"},
+	}, query)
+}
+
+func TestEncodeQueryTypes(t *testing.T) {
+	no := false
+	params := struct {
+		Since    time.Time  `url:"since"`
+		Before   *time.Time `url:"before"`
+		Zero     time.Time  `url:"zero"`
+		Flag     *bool      `url:"flag"`
+		Unset    *bool      `url:"unset"`
+		IDs      []int      `url:"ids"`
+		Rate     float64    `url:"rate"`
+		Ignored  string
+		Skipped  string `url:"-"`
+		internal string `url:"internal"`
+	}{
+		Since:    time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+		Flag:     &no,
+		IDs:      []int{1, 2},
+		Rate:     0.5,
+		Ignored:  "x",
+		Skipped:  "y",
+		internal: "z",
+	}
+
+	assert.Equal(t, map[string]string{
+		"since":    "2020-01-02T03:04:05Z",
+		"flag":     "false",
+		"ids":      "1,2",
+		"rate":     "0.5",
+		"internal": "z",
+	}, encodeQuery(&params))
+}
+
+// TestQueryParamsDeclareParams checks that every type with url tags has a
+// Params method of its own, as one promoted from an embedded type would
+// leave its fields out.
+func TestQueryParamsDeclareParams(t *testing.T) {
+	files, err := filepath.Glob("*.go")
+	fatalIf(t, err)
+
+	tagged := make(map[string]bool)
+	declared := make(map[string]bool)
+	fset := token.NewFileSet()
+	for _, name := range files {
+		if strings.HasSuffix(name, "_test.go") {
+			continue
+		}
+		f, err := parser.ParseFile(fset, name, nil, 0)
+		fatalIf(t, err)
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.TypeSpec:
+				if st, ok := n.Type.(*ast.StructType); ok {
+					for _, field := range st.Fields.List {
+						if field.Tag == nil {
+							continue
+						}
+						tag := reflect.StructTag(strings.Trim(field.Tag.Value, "`")).Get("url")
+						if tag != "" && tag != "-" {
+							tagged[n.Name.Name] = true
+						}
+					}
+				}
+			case *ast.FuncDecl:
+				if n.Recv != nil && n.Name.Name == "Params" {
+					recv := n.Recv.List[0].Type
+					if star, ok := recv.(*ast.StarExpr); ok {
+						recv = star.X
+					}
+					if ident, ok := recv.(*ast.Ident); ok {
+						declared[ident.Name] = true
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	assert.Contains(t, tagged, "ListGetMembersParams")
+	for name := range tagged {
+		assert.True(t, declared[name], "%s has url tags but no Params method of its own", name)
+	}
+}
@@ -0,0 +1,69 @@
+package gochimp3
+
+import (
+	"context"
+	"net/http"
+	"time"
+)
+
+// ResponseInfo describes the HTTP exchange behind a call. Attach one to the
+// call's context with WithResponseInfo to have it filled in:
+//
+//	var info gochimp3.ResponseInfo
+//	member, err := list.GetMember(gochimp3.WithResponseInfo(ctx, &info), id, nil)
+//	log.Println(info.RequestID, info.StatusCode, info.Latency)
+//
+// It is filled in for failed calls too. StatusCode, Header and RequestID
+// describe the last attempt, and are left empty when it got no response.
+type ResponseInfo struct {
+	StatusCode int
+	Header     http.Header
+
+	// RequestID is the identifier Mailchimp assigns to every request, useful
+	// when contacting their support.
+	RequestID string
+
+	// Latency is the duration of the last attempt, from sending the request
+	// to reading the whole response body.
+	Latency time.Duration
+
+	// QueueWait is the time spent waiting on the API's Limiter, summed over
+	// all attempts.
+	QueueWait time.Duration
+
+	// Attempts is the number of round trips made, including retries.
+	Attempts int
+}
+
+type responseInfoKey struct{}
+
+// WithResponseInfo returns a context that makes calls made with it fill in
+// info. The same info is overwritten by every call using the context.
+func WithResponseInfo(ctx context.Context, info *ResponseInfo) context.Context {
+	return context.WithValue(ctx, responseInfoKey{}, info)
+}
+
+// responseInfoFrom returns the ResponseInfo attached to ctx, reset for a new
+// call, or a throwaway one if there is none.
+func responseInfoFrom(ctx context.Context) *ResponseInfo {
+	info, ok := ctx.Value(responseInfoKey{}).(*ResponseInfo)
+	if !ok || info == nil {
+		return new(ResponseInfo)
+	}
+
+	*info = ResponseInfo{}
+	return info
+}
+
+func (info *ResponseInfo) record(resp *http.Response, latency time.Duration) {
+	info.Attempts++
+	info.Latency = latency
+	info.StatusCode, info.Header, info.RequestID = 0, nil, ""
+	if resp == nil {
+		return
+	}
+
+	info.StatusCode = resp.StatusCode
+	info.Header = resp.Header
+	info.RequestID = resp.Header.Get("X-Request-Id")
+}
@@ -0,0 +1,97 @@
+package gochimp3
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestResponseInfo(t *testing.T) {
+	calls := 0
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("X-Request-Id", "req-123")
+		w.Header().Set("X-RateLimit-Remaining", "9")
+		if calls == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	api := testAPI()
+	api.Retry = testRetryPolicy()
+
+	var info ResponseInfo
+	ok, err := api.requestOk(WithResponseInfo(t.Context(), &info), "DELETE", "/somewhere")
+	fatalIf(t, err)
+	assert.True(t, ok)
+
+	assert.Equal(t, http.StatusNoContent, info.StatusCode)
+	assert.Equal(t, "req-123", info.RequestID)
+	assert.Equal(t, "9", info.Header.Get("X-RateLimit-Remaining"))
+	assert.Equal(t, 2, info.Attempts)
+	assert.Greater(t, int64(info.Latency), int64(0))
+}
+
+func TestResponseInfoOnError(t *testing.T) {
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("X-Request-Id", "req-456")
+		http.Error(w, `{"title": "Resource Not Found", "status": 404}`, http.StatusNotFound)
+	}
+
+	api := testAPI()
+
+	var info ResponseInfo
+	_, err := api.requestOk(WithResponseInfo(t.Context(), &info), "GET", "/somewhere")
+	assert.ErrorIs(t, err, ErrNotFound)
+	assert.Equal(t, http.StatusNotFound, info.StatusCode)
+	assert.Equal(t, "req-456", info.RequestID)
+	assert.Equal(t, 1, info.Attempts)
+}
+
+// roundTripFunc lets tests script what a transport returns.
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+// rateLimitedThenReset answers the first request with a 429 and fails every
+// other one like a reset connection.
+func rateLimitedThenReset() http.RoundTripper {
+	calls := 0
+	return roundTripFunc(func(r *http.Request) (*http.Response, error) {
+		calls++
+		if calls > 1 {
+			return nil, errors.New("connection reset by peer")
+		}
+		return &http.Response{
+			StatusCode: http.StatusTooManyRequests,
+			Header:     http.Header{"X-Request-Id": {"req-429"}},
+			Body:       io.NopCloser(strings.NewReader(`{"title":"Too Many Requests","status":429}`)),
+			Request:    r,
+		}, nil
+	})
+}
+
+func TestResponseInfoAfterTransportError(t *testing.T) {
+	api, err := NewWithOptions("apikey", WithBaseURL("http://mailchimp.invalid"), WithAPIVersion(""),
+		WithHTTPClient(&http.Client{Transport: rateLimitedThenReset()}))
+	fatalIf(t, err)
+	api.Retry = testRetryPolicy()
+	api.Retry.MaxAttempts = 2
+
+	var info ResponseInfo
+	_, err = api.GetRoot(WithResponseInfo(t.Context(), &info), nil)
+	assert.ErrorContains(t, err, "connection reset")
+
+	assert.Equal(t, 2, info.Attempts)
+	assert.Equal(t, 0, info.StatusCode)
+	assert.Nil(t, info.Header)
+	assert.Empty(t, info.RequestID)
+}
@@ -0,0 +1,131 @@
+package gochimp3
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  5 * time.Millisecond,
+	}
+}
+
+func TestRetryOnServerError(t *testing.T) {
+	calls := 0
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		if calls < 3 {
+			http.Error(w, `{"status": 503, "title": "Service Unavailable"}`, 503)
+			return
+		}
+		w.Write([]byte(`{"one": "thing"}`))
+	}
+
+	api := testAPI()
+	api.Retry = testRetryPolicy()
+
+	actual := make(map[string]interface{})
+	err := api.request(t.Context(), "GET", "/somewhere", nil, nil, &actual)
+	fatalIf(t, err)
+
+	assert.Equal(t, 3, calls)
+	assert.Equal(t, "thing", actual["one"])
+}
+
+func TestRetryGivesUpAfterMaxAttempts(t *testing.T) {
+	calls := 0
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		http.Error(w, `{"status": 500, "title": "Internal Server Error"}`, 500)
+	}
+
+	api := testAPI()
+	api.Retry = testRetryPolicy()
+
+	ok, err := api.requestOk(t.Context(), "DELETE", "/somewhere")
+	assert.False(t, ok)
+	assert.NotNil(t, err)
+	assert.Equal(t, 3, calls)
+}
+
+func TestRetryRebuildsBody(t *testing.T) {
+	var bodies []string
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		buf := make([]byte, 64)
+		n, _ := r.Body.Read(buf)
+		bodies = append(bodies, string(buf[:n]))
+		if len(bodies) == 1 {
+			w.WriteHeader(http.StatusTooManyRequests)
+		}
+	}
+
+	api := testAPI()
+	api.Retry = testRetryPolicy()
+	api.Retry.RetryPOST = true
+
+	err := api.request(t.Context(), "POST", "/somewhere", nil, map[string]string{"a": "b"}, nil)
+	fatalIf(t, err)
+
+	assert.Equal(t, []string{`{"a":"b"}`, `{"a":"b"}`}, bodies)
+}
+
+func TestRetrySkipsPOSTByDefault(t *testing.T) {
+	calls := 0
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+
+	api := testAPI()
+	api.Retry = testRetryPolicy()
+
+	err := api.request(t.Context(), "POST", "/somewhere", nil, nil, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+}
+
+func TestRetryAfterBeyondDeadline(t *testing.T) {
+	calls := 0
+	delegate = func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		w.Header().Set("Retry-After", "60")
+		w.WriteHeader(http.StatusTooManyRequests)
+	}
+
+	api := testAPI()
+	api.Retry = testRetryPolicy()
+
+	ctx, cancel := context.WithTimeout(t.Context(), time.Second)
+	defer cancel()
+
+	start := time.Now()
+	err := api.request(ctx, "GET", "/somewhere", nil, nil, nil)
+	assert.NotNil(t, err)
+	assert.Equal(t, 1, calls)
+	assert.Less(t, time.Since(start), time.Second)
+}
+
+func TestRetryAfterHeader(t *testing.T) {
+	resp := &http.Response{Header: http.Header{}}
+
+	resp.Header.Set("Retry-After", "7")
+	d, ok := retryAfter(resp)
+	assert.True(t, ok)
+	assert.Equal(t, 7*time.Second, d)
+
+	resp.Header.Set("Retry-After", time.Now().Add(-time.Hour).UTC().Format(http.TimeFormat))
+	d, ok = retryAfter(resp)
+	assert.True(t, ok)
+	assert.Equal(t, time.Duration(0), d)
+
+	resp.Header.Set("Retry-After", "soon")
+	_, ok = retryAfter(resp)
+	assert.False(t, ok)
+}
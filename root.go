@@ -1,6 +1,9 @@
 package gochimp3
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 const (
 	root_path = "/"
@@ -14,12 +17,26 @@ type AccountContact struct {
 	State   string `json:"state"`
 	Zip     string `json:"zip"`
 	Country string `json:"country"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *AccountContact) UnmarshalJSON(data []byte) error {
+	type alias AccountContact
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type IndustryStats struct {
 	OpenRate   float64 `json:"open_rate"`
 	BounceRate float64 `json:"bounce_rate"`
 	ClickRate  float64 `json:"click_rate"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *IndustryStats) UnmarshalJSON(data []byte) error {
+	type alias IndustryStats
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 // RootResponse - https://developer.mailchimp.com/documentation/mailchimp/reference/root/#read-get_root
@@ -30,16 +47,23 @@ type RootResponse struct {
 	Role             string         `json:"role"`
 	Contact          AccountContact `json:"contact"`
 	ProEnabled       bool           `json:"pro_enabled"`
-	LastLogin        string         `json:"last_login"`
+	LastLogin        Time           `json:"last_login"`
 	TotalSubscribers int            `json:"total_subscribers"`
 	IndustyStats     IndustryStats  `json:"industry_stats"`
 	Links            []Link         `json:"_links"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *RootResponse) UnmarshalJSON(data []byte) error {
+	type alias RootResponse
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 // GetRoot queries the root of the API for stats
 func (api *API) GetRoot(ctx context.Context, params *BasicQueryParams) (*RootResponse, error) {
 	response := new(RootResponse)
-	err := api.request(ctx, "GET", root_path, params, nil, response)
+	err := api.do(ctx, newOperation("GetRoot", "GET", root_path), params, nil, response)
 	if err != nil {
 		return nil, err
 	}
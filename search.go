@@ -1,6 +1,9 @@
 package gochimp3
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 const (
 	search_members_path = "/search-members"
@@ -9,26 +12,37 @@ const (
 type SearchMembersQueryParams struct {
 	BasicQueryParams
 
-	Query  string
-	listID string
+	Query  string `url:"query"`
+	listID string `url:"list_id"`
 }
 
 func (q *SearchMembersQueryParams) Params() map[string]string {
-	m := q.BasicQueryParams.Params()
-	m["query"] = q.Query
-	m["list_id"] = q.listID
-	return m
+	return encodeQuery(q)
 }
 
 type SearchMembersResponse struct {
 	ExactMatches Matches `json:"exact_matches"`
 	FullSearch   Matches `json:"full_search"`
 	Links        []Link  `json:"_links"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *SearchMembersResponse) UnmarshalJSON(data []byte) error {
+	type alias SearchMembersResponse
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type Matches struct {
 	Members    []Member `json:"members"`
 	TotalItems int64    `json:"total_items"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *Matches) UnmarshalJSON(data []byte) error {
+	type alias Matches
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (list *ListResponse) SearchMembers(ctx context.Context, params *SearchMembersQueryParams) (*SearchMembersResponse, error) {
@@ -36,7 +50,7 @@ func (list *ListResponse) SearchMembers(ctx context.Context, params *SearchMembe
 
 	params.listID = list.ID
 
-	err := list.api.request(ctx, "GET", search_members_path, params, nil, response)
+	err := list.api.do(ctx, newOperation("ListResponse.SearchMembers", "GET", search_members_path), params, nil, response)
 	if err != nil {
 		return nil, err
 	}
@@ -2,7 +2,8 @@ package gochimp3
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
+	"time"
 )
 
 const (
@@ -15,6 +16,13 @@ type ListOfSegments struct {
 
 	Segments []Segment `json:"segments"`
 	ListID   string    `json:"list_id"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfSegments) UnmarshalJSON(data []byte) error {
+	type alias ListOfSegments
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type SegmentRequest struct {
@@ -23,22 +31,53 @@ type SegmentRequest struct {
 	Options       *SegmentOptions `json:"options,omitempty"`
 }
 
+// Validate checks req for creating a segment. Updates only check the fields
+// they set.
+func (req SegmentRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("name", req.Name)
+	return errs.merge(req.validatePartial())
+}
+
+func (req SegmentRequest) validatePartial() error {
+	var errs fieldErrors
+	if req.Options != nil {
+		errs.oneOf("options.match", req.Options.Match, CONDITION_MATCH_ANY, CONDITION_MATCH_ALL)
+	}
+	return errs.err()
+}
+
 type Segment struct {
 	SegmentRequest
+	noValidate
 
 	ID          string `json:"id"`
 	MemberCount int    `json:"member_count"`
 	Type        string `json:"type"`
-	CreatedAt   string `json:"created_at"`
-	UpdatedAt   string `json:"updated_at"`
+	CreatedAt   Time   `json:"created_at"`
+	UpdatedAt   Time   `json:"updated_at"`
 	ListID      string `json:"list_id"`
 
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *Segment) UnmarshalJSON(data []byte) error {
+	type alias Segment
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type SegmentOptions struct {
 	Match      string               `json:"match"`
 	Conditions []SegmentConditional `json:"conditions"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *SegmentOptions) UnmarshalJSON(data []byte) error {
+	type alias SegmentOptions
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 // SegmentBatchRequest represents arguments for bach modifying a static
@@ -49,6 +88,17 @@ type SegmentBatchRequest struct {
 	MembersToRemove []string `json:"members_to_remove"`
 }
 
+func (req SegmentBatchRequest) Validate() error {
+	var errs fieldErrors
+	if req.MembersToAdd == nil {
+		errs.add("members_to_add", "must not be nil")
+	}
+	if req.MembersToRemove == nil {
+		errs.add("members_to_remove", "must not be nil")
+	}
+	return errs.err()
+}
+
 // SegmentBatchResponse is the object returned by MailChimp from a request to
 // batch modify a static segment
 type SegmentBatchResponse struct {
@@ -62,6 +112,13 @@ type SegmentBatchResponse struct {
 	ErrorCount   int `json:"error_count"`
 
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *SegmentBatchResponse) UnmarshalJSON(data []byte) error {
+	type alias SegmentBatchResponse
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 // SegmentBatchError contains errors returned from batch modifying a static
@@ -69,6 +126,13 @@ type SegmentBatchResponse struct {
 type SegmentBatchError struct {
 	EmailAddresses []string `json:"email_addresses"`
 	Error          string   `json:"error"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *SegmentBatchError) UnmarshalJSON(data []byte) error {
+	type alias SegmentBatchError
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 // SegmentConditional represents parameters to filter by
@@ -76,28 +140,27 @@ type SegmentConditional struct {
 	Field string      `json:"field"`
 	OP    string      `json:"op"`
 	Value interface{} `json:"value"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *SegmentConditional) UnmarshalJSON(data []byte) error {
+	type alias SegmentConditional
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type SegmentQueryParams struct {
 	ExtendedQueryParams
 
-	Type            string
-	SinceCreatedAt  string
-	BeforeCreatedAt string
-	SinceUpdatedAt  string
-	BeforeUpdatedAt string
+	Type            string    `url:"type"`
+	SinceCreatedAt  time.Time `url:"since_created_at"`
+	BeforeCreatedAt time.Time `url:"before_created_at"`
+	SinceUpdatedAt  time.Time `url:"since_updated_at"`
+	BeforeUpdatedAt time.Time `url:"before_updated_at"`
 }
 
 func (q *SegmentQueryParams) Params() map[string]string {
-	m := q.ExtendedQueryParams.Params()
-
-	m["type"] = q.Type
-	m["since_created_at"] = q.SinceCreatedAt
-	m["since_updated_at"] = q.SinceUpdatedAt
-	m["before_created_at"] = q.BeforeCreatedAt
-	m["before_updated_at"] = q.BeforeUpdatedAt
-
-	return m
+	return encodeQuery(q)
 }
 
 func (list *ListResponse) GetSegments(ctx context.Context, params *SegmentQueryParams) (*ListOfSegments, error) {
@@ -105,10 +168,10 @@ func (list *ListResponse) GetSegments(ctx context.Context, params *SegmentQueryP
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(segments_path, list.ID)
+	op := newOperation("ListResponse.GetSegments", "GET", segments_path, list.ID)
 	response := new(ListOfSegments)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (list *ListResponse) GetSegment(ctx context.Context, id string, params *BasicQueryParams) (*Segment, error) {
@@ -116,10 +179,10 @@ func (list *ListResponse) GetSegment(ctx context.Context, id string, params *Bas
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_segment_path, list.ID, id)
+	op := newOperation("ListResponse.GetSegment", "GET", single_segment_path, list.ID, id)
 	response := new(Segment)
 
-	return response, list.api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, list.api.do(ctx, op, params, nil, response)
 }
 
 func (list *ListResponse) CreateSegment(ctx context.Context, body *SegmentRequest) (*Segment, error) {
@@ -127,10 +190,10 @@ func (list *ListResponse) CreateSegment(ctx context.Context, body *SegmentReques
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(segments_path, list.ID)
+	op := newOperation("ListResponse.CreateSegment", "POST", segments_path, list.ID)
 	response := new(Segment)
 
-	return response, list.api.request(ctx, "POST", endpoint, nil, &body, response)
+	return response, list.api.do(ctx, op, nil, &body, response)
 }
 
 func (list *ListResponse) UpdateSegment(ctx context.Context, id string, body *SegmentRequest) (*Segment, error) {
@@ -138,10 +201,10 @@ func (list *ListResponse) UpdateSegment(ctx context.Context, id string, body *Se
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_segment_path, list.ID, id)
+	op := newOperation("ListResponse.UpdateSegment", "PATCH", single_segment_path, list.ID, id)
 	response := new(Segment)
 
-	return response, list.api.request(ctx, "PATCH", endpoint, nil, &body, response)
+	return response, list.api.do(ctx, op, nil, &body, response)
 }
 
 // BatchModifySegment adds and/or removes one or more emails from a static
@@ -153,10 +216,10 @@ func (list *ListResponse) BatchModifySegment(ctx context.Context, id string, bod
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_segment_path, list.ID, id)
+	op := newOperation("ListResponse.BatchModifySegment", "POST", single_segment_path, list.ID, id)
 	response := new(SegmentBatchResponse)
 
-	return response, list.api.request(ctx, "POST", endpoint, nil, &body, response)
+	return response, list.api.do(ctx, op, nil, &body, response)
 }
 
 func (list *ListResponse) DeleteSegment(ctx context.Context, id string) (bool, error) {
@@ -164,6 +227,6 @@ func (list *ListResponse) DeleteSegment(ctx context.Context, id string) (bool, e
 		return false, err
 	}
 
-	endpoint := fmt.Sprintf(single_segment_path, list.ID, id)
-	return list.api.requestOk(ctx, "DELETE", endpoint)
+	op := newOperation("ListResponse.DeleteSegment", "DELETE", single_segment_path, list.ID, id)
+	return list.api.doOk(ctx, op)
 }
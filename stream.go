@@ -0,0 +1,128 @@
+package gochimp3
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+)
+
+// ErrResponseTooLarge is returned when a response body is bigger than
+// API.MaxResponseBytes.
+var ErrResponseTooLarge = errors.New("gochimp3: response body exceeds MaxResponseBytes")
+
+// limitedBody reads at most n bytes, failing with ErrResponseTooLarge
+// rather than silently truncating like io.LimitReader.
+type limitedBody struct {
+	r io.Reader
+	n int64
+}
+
+func (l *limitedBody) Read(p []byte) (int, error) {
+	if l.n <= 0 {
+		var b [1]byte
+		n, err := l.r.Read(b[:])
+		if n > 0 {
+			return 0, ErrResponseTooLarge
+		}
+		return 0, err
+	}
+
+	if int64(len(p)) > l.n {
+		p = p[:l.n]
+	}
+	n, err := l.r.Read(p)
+	l.n -= int64(n)
+	return n, err
+}
+
+// streamDecoder is implemented by responses that decode themselves from the
+// body as it is read.
+type streamDecoder interface {
+	decodeStream(dec *json.Decoder) error
+}
+
+// decodeResponse decodes a single JSON value from r into v. An empty body
+// leaves v untouched.
+func decodeResponse(r io.Reader, v interface{}) error {
+	dec := json.NewDecoder(r)
+
+	var err error
+	if s, ok := v.(streamDecoder); ok {
+		err = s.decodeStream(dec)
+	} else {
+		err = dec.Decode(v)
+	}
+
+	if err == io.EOF {
+		return nil
+	}
+	return err
+}
+
+// decodeStreamed decodes a JSON object into v, except for the array under
+// key, whose elements are handed to elem one at a time as they are read.
+// Only the rest of the object is held in memory.
+func decodeStreamed(dec *json.Decoder, v interface{}, key string, elem func(*json.Decoder) error) error {
+	if err := expectDelim(dec, '{'); err != nil {
+		return err
+	}
+
+	rest := make(map[string]json.RawMessage)
+	for dec.More() {
+		tok, err := dec.Token()
+		if err != nil {
+			return err
+		}
+		name, _ := tok.(string)
+
+		if name != key {
+			var raw json.RawMessage
+			if err := dec.Decode(&raw); err != nil {
+				return err
+			}
+			rest[name] = raw
+			continue
+		}
+
+		tok, err = dec.Token()
+		if err != nil {
+			return err
+		}
+		if tok == nil {
+			continue
+		}
+		if d, ok := tok.(json.Delim); !ok || d != '[' {
+			return fmt.Errorf("gochimp3: expected an array for %q, got %v", key, tok)
+		}
+
+		for dec.More() {
+			if err := elem(dec); err != nil {
+				return err
+			}
+		}
+		if err := expectDelim(dec, ']'); err != nil {
+			return err
+		}
+	}
+	if err := expectDelim(dec, '}'); err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(rest)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(data, v)
+}
+
+func expectDelim(dec *json.Decoder, want json.Delim) error {
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+	if d, ok := tok.(json.Delim); !ok || d != want {
+		return fmt.Errorf("gochimp3: expected %v, got %v", want, tok)
+	}
+	return nil
+}
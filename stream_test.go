@@ -0,0 +1,85 @@
+package gochimp3
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func membersPage(n int) string {
+	members := make([]string, n)
+	for i := range members {
+		members[i] = fmt.Sprintf(`{"id":"m%d","email_address":"m%d@example.com","merge_fields":{"FNAME":"M%d"}}`, i, i, i)
+	}
+	return `{"list_id":"abc","members":[` + strings.Join(members, ",") + `],"total_items":42}`
+}
+
+func streamAPI(t *testing.T, body string) *API {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(srv.Close)
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+	return api
+}
+
+func TestStreamMembers(t *testing.T) {
+	for _, debug := range []bool{false, true} {
+		api := streamAPI(t, membersPage(3))
+		api.Debug = debug
+
+		var ids []string
+		page, err := api.NewListResponse("abc").StreamMembers(t.Context(), nil, func(m Member) error {
+			ids = append(ids, m.ID)
+			assert.NotNil(t, m.api)
+			return nil
+		})
+		fatalIf(t, err)
+
+		assert.Equal(t, []string{"m0", "m1", "m2"}, ids)
+		assert.Equal(t, 42, page.TotalItems)
+		assert.Equal(t, "abc", page.ListID)
+		assert.Nil(t, page.Members)
+	}
+}
+
+func TestStreamMembersStops(t *testing.T) {
+	api := streamAPI(t, membersPage(5))
+
+	stop := errors.New("stop")
+	calls := 0
+	_, err := api.NewListResponse("abc").StreamMembers(t.Context(), nil, func(m Member) error {
+		calls++
+		if calls == 2 {
+			return stop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, stop)
+	assert.Equal(t, 2, calls)
+}
+
+func TestMaxResponseBytes(t *testing.T) {
+	body := membersPage(50)
+
+	for _, debug := range []bool{false, true} {
+		api := streamAPI(t, body)
+		api.Debug = debug
+		api.MaxResponseBytes = int64(len(body) - 1)
+
+		_, err := api.ListGetMembers(t.Context(), "abc", nil)
+		assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+		api.MaxResponseBytes = int64(len(body))
+		members, err := api.ListGetMembers(t.Context(), "abc", nil)
+		fatalIf(t, err)
+		assert.Len(t, members.Members, 50)
+	}
+}
@@ -1,6 +1,9 @@
 package gochimp3
 
-import "context"
+import (
+	"context"
+	"encoding/json"
+)
 
 const (
 	template_folders_path = "/template-folders"
@@ -14,6 +17,13 @@ type TemplateFolderQueryParams struct {
 type ListOfTemplateFolders struct {
 	baseList
 	Folders []TemplateFolder `json:"folders"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfTemplateFolders) UnmarshalJSON(data []byte) error {
+	type alias ListOfTemplateFolders
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type TemplateFolder struct {
@@ -23,23 +33,36 @@ type TemplateFolder struct {
 	ID    string `json:"id"`
 	Count uint   `json:"count"`
 
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (v *TemplateFolder) UnmarshalJSON(data []byte) error {
+	type alias TemplateFolder
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
+}
+
 type TemplateFolderCreationRequest struct {
 	Name string `json:"name"`
 }
 
+func (req TemplateFolderCreationRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("name", req.Name)
+	return errs.err()
+}
+
 func (api *API) GetTemplateFolders(ctx context.Context, params *TemplateFolderQueryParams) (*ListOfTemplateFolders, error) {
 	response := new(ListOfTemplateFolders)
 
-	err := api.request(ctx, "GET", template_folders_path, params, nil, response)
+	err := api.do(ctx, newOperation("GetTemplateFolders", "GET", template_folders_path), params, nil, response)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, l := range response.Folders {
-		l.api = api
+	for i := range response.Folders {
+		response.Folders[i].api = api
 	}
 
 	return response, nil
@@ -48,5 +71,5 @@ func (api *API) GetTemplateFolders(ctx context.Context, params *TemplateFolderQu
 func (api *API) CreateTemplateFolder(ctx context.Context, body *TemplateFolderCreationRequest) (*TemplateFolder, error) {
 	response := new(TemplateFolder)
 	response.api = api
-	return response, api.request(ctx, "POST", template_folders_path, nil, body, response)
+	return response, api.do(ctx, newOperation("CreateTemplateFolder", "POST", template_folders_path), nil, body, response)
 }
@@ -2,8 +2,9 @@ package gochimp3
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
-	"fmt"
+	"time"
 )
 
 const (
@@ -15,27 +16,27 @@ const (
 type TemplateQueryParams struct {
 	ExtendedQueryParams
 
-	CreatedBy       string
-	SinceCreatedAt  string
-	BeforeCreatedAt string
-	Type            string
-	FolderId        string
+	CreatedBy       string    `url:"created_by"`
+	SinceCreatedAt  time.Time `url:"since_created_at"`
+	BeforeCreatedAt time.Time `url:"before_created_at"`
+	Type            string    `url:"type"`
+	FolderId        string    `url:"folder_id"`
 }
 
 func (q *TemplateQueryParams) Params() map[string]string {
-	m := q.ExtendedQueryParams.Params()
-	m["created_by"] = q.CreatedBy
-	m["since_created_at"] = q.SinceCreatedAt
-	m["before_created_at"] = q.BeforeCreatedAt
-	m["type"] = q.Type
-	m["folder_id"] = q.FolderId
-
-	return m
+	return encodeQuery(q)
 }
 
 type ListOfTemplates struct {
 	baseList
 	Templates []TemplateResponse `json:"templates"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfTemplates) UnmarshalJSON(data []byte) error {
+	type alias ListOfTemplates
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type TemplateResponse struct {
@@ -47,30 +48,54 @@ type TemplateResponse struct {
 	DragAndDrop bool   `json:"drag_and_drop"`
 	Responsive  bool   `json:"responsive"`
 	Category    string `json:"category"`
-	DateCreated string `json:"date_created"`
+	DateCreated Time   `json:"date_created"`
+	DateEdited  Time   `json:"date_edited"`
 	CreatedBy   string `json:"created_by"`
-	Active      bool   `json:"activer"`
+	EditedBy    string `json:"edited_by"`
+	Active      bool   `json:"active"`
+	ContentType string `json:"content_type"`
 	FolderId    string `json:"folder_id"`
 	Thumbnail   string `json:"thumbnail"`
 	ShareUrl    string `json:"share_url"`
 
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (template *TemplateResponse) UnmarshalJSON(data []byte) error {
+	type alias TemplateResponse
+	return unmarshalWithExtra(data, (*alias)(template), &template.Extra)
+}
+
 type TemplateCreationRequest struct {
 	Name     string `json:"name"`
 	Html     string `json:"html"`
 	FolderId string `json:"folder_id"`
 }
 
+func (req TemplateCreationRequest) Validate() error {
+	var errs fieldErrors
+	errs.required("name", req.Name)
+	errs.required("html", req.Html)
+	return errs.err()
+}
+
 type TemplateDefaultContentResponse struct {
 	withLinks
 
 	Sections map[string]string `json:"sections"`
 
+	Extra map[string]json.RawMessage `json:"-"`
+
 	api *API
 }
 
+func (v *TemplateDefaultContentResponse) UnmarshalJSON(data []byte) error {
+	type alias TemplateDefaultContentResponse
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
+}
+
 func (template *TemplateResponse) CanMakeRequest() error {
 	if template.ID == 0 {
 		return errors.New("No ID provided on template")
@@ -82,50 +107,50 @@ func (template *TemplateResponse) CanMakeRequest() error {
 func (api *API) GetTemplates(ctx context.Context, params *TemplateQueryParams) (*ListOfTemplates, error) {
 	response := new(ListOfTemplates)
 
-	err := api.request(ctx, "GET", templates_path, params, nil, response)
+	err := api.do(ctx, newOperation("GetTemplates", "GET", templates_path), params, nil, response)
 	if err != nil {
 		return nil, err
 	}
 
-	for _, l := range response.Templates {
-		l.api = api
+	for i := range response.Templates {
+		response.Templates[i].api = api
 	}
 
 	return response, nil
 }
 
 func (api *API) GetTemplate(ctx context.Context, id string, params *BasicQueryParams) (*TemplateResponse, error) {
-	endpoint := fmt.Sprintf(single_template_path, id)
+	op := newOperation("GetTemplate", "GET", single_template_path, id)
 
 	response := new(TemplateResponse)
 	response.api = api
 
-	return response, api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, api.do(ctx, op, params, nil, response)
 }
 
 func (api *API) CreateTemplate(ctx context.Context, body *TemplateCreationRequest) (*TemplateResponse, error) {
 	response := new(TemplateResponse)
 	response.api = api
-	return response, api.request(ctx, "POST", templates_path, nil, body, response)
+	return response, api.do(ctx, newOperation("CreateTemplate", "POST", templates_path), nil, body, response)
 }
 
 func (api *API) UpdateTemplate(ctx context.Context, id string, body *TemplateCreationRequest) (*TemplateResponse, error) {
-	endpoint := fmt.Sprintf(single_template_path, id)
+	op := newOperation("UpdateTemplate", "PATCH", single_template_path, id)
 
 	response := new(TemplateResponse)
 	response.api = api
 
-	return response, api.request(ctx, "PATCH", endpoint, nil, body, response)
+	return response, api.do(ctx, op, nil, body, response)
 }
 
 func (api *API) DeleteTemplate(ctx context.Context, id string) (bool, error) {
-	endpoint := fmt.Sprintf(single_template_path, id)
-	return api.requestOk(ctx, "DELETE", endpoint)
+	op := newOperation("DeleteTemplate", "DELETE", single_template_path, id)
+	return api.doOk(ctx, op)
 }
 
 func (api *API) GetTemplateDefaultContent(ctx context.Context, id string, params *BasicQueryParams) (*TemplateDefaultContentResponse, error) {
-	endpoint := fmt.Sprintf(template_default_path, id)
+	op := newOperation("GetTemplateDefaultContent", "GET", template_default_path, id)
 	response := new(TemplateDefaultContentResponse)
 	response.api = api
-	return response, api.request(ctx, "GET", endpoint, params, nil, response)
+	return response, api.do(ctx, op, params, nil, response)
 }
@@ -0,0 +1,60 @@
+package gochimp3
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTimeUnmarshal(t *testing.T) {
+	want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	tests := []struct {
+		in   string
+		want time.Time
+	}{
+		{`"2020-01-02T03:04:05+00:00"`, want},
+		{`"2020-01-02T03:04:05Z"`, want},
+		{`"2020-01-02T03:04:05.000Z"`, want},
+		{`"2020-01-02T05:04:05+0200"`, want},
+		{`"2020-01-02T03:04:05"`, want},
+		{`"2020-01-02 03:04:05"`, want},
+		{`"2020-01-02"`, time.Date(2020, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{`""`, time.Time{}},
+		{`null`, time.Time{}},
+	}
+
+	for _, tt := range tests {
+		var got Time
+		fatalIf(t, json.Unmarshal([]byte(tt.in), &got))
+		assert.True(t, tt.want.Equal(got.Time), "%s gave %s", tt.in, got)
+	}
+
+	var got Time
+	assert.NotNil(t, json.Unmarshal([]byte(`"yesterday"`), &got))
+	assert.NotNil(t, json.Unmarshal([]byte(`12`), &got))
+}
+
+func TestTimeMarshal(t *testing.T) {
+	data, err := json.Marshal(Time{time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)})
+	fatalIf(t, err)
+	assert.Equal(t, `"2020-01-02T03:04:05+00:00"`, string(data))
+
+	data, err = json.Marshal(Time{})
+	fatalIf(t, err)
+	assert.Equal(t, `""`, string(data))
+
+	data, err = json.Marshal(BatchOperationResponse{ID: "b1"})
+	fatalIf(t, err)
+	assert.NotContains(t, string(data), "submitted_at")
+}
+
+func TestResponseTimes(t *testing.T) {
+	var m Member
+	fatalIf(t, json.Unmarshal([]byte(`{"last_changed":"2020-01-02T03:04:05+00:00","timestamp_opt":"","timestamp_signup":"2019-05-06 07:08:09"}`), &m))
+	assert.Equal(t, 2020, m.LastChanged.Year())
+	assert.True(t, m.TimestampOpt.IsZero())
+	assert.Equal(t, time.May, m.TimestampSignup.Month())
+}
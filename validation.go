@@ -0,0 +1,135 @@
+package gochimp3
+
+import (
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+	"strings"
+)
+
+// Validator is implemented by request bodies that can be checked before
+// they're sent. API calls Validate on every POST body unless
+// DisableValidation is set.
+type Validator interface {
+	Validate() error
+}
+
+// partialValidator is implemented by request bodies also sent by PUT and
+// PATCH, where only the fields that are set are checked. Bodies without it
+// aren't checked in those requests.
+type partialValidator interface {
+	validatePartial() error
+}
+
+// noValidate is embedded in response types next to the request type they
+// embed, so that the request's Validate, being ambiguous, isn't promoted to
+// them.
+type noValidate struct{}
+
+func (noValidate) Validate() error { return nil }
+
+// ValidationError is returned when a request body fails validation, in
+// which case nothing is sent. Like the APIError Mailchimp would have
+// returned, it lists the offending fields and matches ErrBadRequest and
+// ErrInvalidResource.
+type ValidationError struct {
+	Errors []FieldError `json:"errors"`
+}
+
+func (err *ValidationError) Error() string {
+	msgs := make([]string, len(err.Errors))
+	for i, e := range err.Errors {
+		msgs[i] = e.String()
+	}
+	return "Invalid Resource: " + strings.Join(msgs, "; ")
+}
+
+// Is reports whether err matches one of the sentinel errors.
+func (err *ValidationError) Is(target error) bool {
+	return matchesSentinel(target, http.StatusBadRequest, "Invalid Resource")
+}
+
+// validate checks body, which may be a Validator or any number of pointers
+// to one, for a request with the given method.
+func validate(method string, body interface{}) error {
+	v := reflect.ValueOf(body)
+	for v.IsValid() {
+		if v.Kind() == reflect.Ptr && v.IsNil() {
+			return nil
+		}
+		if method == "POST" {
+			if validator, ok := v.Interface().(Validator); ok {
+				return validator.Validate()
+			}
+		} else if validator, ok := v.Interface().(partialValidator); ok {
+			return validator.validatePartial()
+		}
+		if v.Kind() != reflect.Ptr {
+			return nil
+		}
+		v = v.Elem()
+	}
+	return nil
+}
+
+// fieldErrors collects the problems found by a Validate method.
+type fieldErrors []FieldError
+
+func (errs *fieldErrors) add(field, message string) {
+	*errs = append(*errs, FieldError{Field: field, Message: message})
+}
+
+func (errs *fieldErrors) required(field, value string) {
+	if value == "" {
+		errs.add(field, "is required")
+	}
+}
+
+func (errs *fieldErrors) oneOf(field, value string, allowed ...string) {
+	for _, a := range allowed {
+		if value == a {
+			return
+		}
+	}
+	errs.add(field, strconv.Quote(value)+" is not one of "+strings.Join(allowed, ", "))
+}
+
+func (errs *fieldErrors) absoluteURL(field, value string) {
+	if u, err := url.Parse(value); value == "" {
+		errs.add(field, "is required")
+	} else if err != nil || !u.IsAbs() {
+		errs.add(field, "is not an absolute URL")
+	}
+}
+
+// merge adds the errors of err to errs and returns the result as an error.
+func (errs fieldErrors) merge(err error) error {
+	errs.nested("", err)
+	return errs.err()
+}
+
+// nested adds the errors of a nested Validate, prefixing their fields.
+func (errs *fieldErrors) nested(prefix string, err error) {
+	if err == nil {
+		return
+	}
+	verr, ok := err.(*ValidationError)
+	if !ok {
+		errs.add(prefix, err.Error())
+		return
+	}
+	for _, e := range verr.Errors {
+		if prefix != "" {
+			e.Field = prefix + "." + e.Field
+		}
+		errs.add(e.Field, e.Message)
+	}
+}
+
+func (errs fieldErrors) err() error {
+	if len(errs) == 0 {
+		return nil
+	}
+	return &ValidationError{Errors: errs}
+}
@@ -0,0 +1,132 @@
+package gochimp3
+
+import (
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		body   Validator
+		fields []string
+	}{
+		{MemberRequest{Status: "subscribed"}, nil},
+		{MemberRequest{Status: "active", StatusIfNew: "nope", EmailType: "pdf"}, []string{"status", "status_if_new", "email_type"}},
+		{CampaignCreationRequest{Type: CAMPAIGN_TYPE_REGULAR}, nil},
+		{CampaignCreationRequest{Type: "weekly"}, []string{"type"}},
+		{TestEmailRequest{TestEmails: []string{"a@b.c"}, SendType: CAMPAIGN_SEND_TYPE_HTML}, nil},
+		{TestEmailRequest{SendType: "pdf"}, []string{"test_emails", "send_type"}},
+		{SegmentBatchRequest{MembersToAdd: []string{}, MembersToRemove: []string{}}, nil},
+		{SegmentBatchRequest{MembersToAdd: []string{"a@b.c"}}, []string{"members_to_remove"}},
+		{ScheduleCampaignRequest{ScheduleTime: "2020-01-02T03:45:00Z"}, nil},
+		{ScheduleCampaignRequest{ScheduleTime: "2020-01-02T03:40:00Z"}, []string{"schedule_time"}},
+		{MergeFieldRequest{Name: "Birthday", Type: "birthday"}, nil},
+		{MergeFieldRequest{Name: "Birthday", Type: "image"}, []string{"type"}},
+		{BatchSubscribeMembersRequest{Members: []MemberRequest{{Status: "subscribed"}, {Status: "gone"}}}, []string{"members[1].status"}},
+		{memberTagsRequest{Tags: []UpdateMemberTag{{Name: "vip", Status: "on"}}}, []string{"tags[0].status"}},
+		{WebHookRequest{URL: "/hook"}, []string{"url"}},
+		{BatchOperationCreationRequest{Operations: []BatchOperation{{Method: "get"}}}, []string{"operations[0].method", "operations[0].path"}},
+	}
+
+	for _, tt := range tests {
+		err := tt.body.Validate()
+		if tt.fields == nil {
+			assert.NoError(t, err, "%#v", tt.body)
+			continue
+		}
+
+		var verr *ValidationError
+		if !assert.True(t, errors.As(err, &verr), "%#v gave %v", tt.body, err) {
+			continue
+		}
+		var fields []string
+		for _, e := range verr.Errors {
+			fields = append(fields, e.Field)
+		}
+		assert.Equal(t, tt.fields, fields)
+	}
+}
+
+func TestValidationBeforeSending(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+
+	list := &ListResponse{api: api, ID: "abc"}
+	_, err = list.BatchModifySegment(t.Context(), "seg", &SegmentBatchRequest{MembersToAdd: []string{"a@b.c"}})
+	assert.ErrorIs(t, err, ErrInvalidResource)
+	assert.ErrorIs(t, err, ErrBadRequest)
+
+	at := time.Date(2020, 1, 2, 3, 4, 0, 0, time.UTC)
+	_, err = api.ScheduleCampaign(t.Context(), "c1", &at)
+	assert.ErrorIs(t, err, ErrInvalidResource)
+	assert.Equal(t, 0, requests)
+
+	api.DisableValidation = true
+	_, err = api.ScheduleCampaign(t.Context(), "c1", &at)
+	fatalIf(t, err)
+	assert.Equal(t, 1, requests)
+}
+
+func TestValidatePartialUpdates(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, r.Method+" "+string(data))
+		w.Write([]byte(`{}`))
+	}))
+	defer srv.Close()
+
+	api, err := NewWithOptions("apikey", WithBaseURL(srv.URL), WithAPIVersion(""))
+	fatalIf(t, err)
+	list := &ListResponse{api: api, ID: "abc"}
+
+	_, err = api.UpdateCampaign(t.Context(), "c1", &CampaignCreationRequest{
+		Settings: CampaignCreationSettings{SubjectLine: "Hello"},
+	})
+	fatalIf(t, err)
+	assert.NotContains(t, bodies[0], `"type"`)
+
+	_, err = list.UpdateMember(t.Context(), "m1", &MemberRequest{Language: "fr"})
+	fatalIf(t, err)
+	assert.NotContains(t, bodies[1], `"status"`)
+
+	_, err = list.AddOrUpdateMember(t.Context(), "m1", &MemberRequest{EmailAddress: "a@b.c", StatusIfNew: "subscribed"})
+	fatalIf(t, err)
+
+	_, err = list.UpdateSegment(t.Context(), "s1", &SegmentRequest{StaticSegment: []string{"a@b.c"}})
+	fatalIf(t, err)
+	assert.Len(t, bodies, 4)
+
+	// Fields that are set are still checked.
+	_, err = list.UpdateMember(t.Context(), "m1", &MemberRequest{Status: "gone"})
+	assert.ErrorIs(t, err, ErrInvalidResource)
+	_, err = api.UpdateCampaign(t.Context(), "c1", &CampaignCreationRequest{Type: "weekly"})
+	assert.ErrorIs(t, err, ErrInvalidResource)
+
+	// Creating still requires them.
+	_, err = api.CreateCampaign(t.Context(), &CampaignCreationRequest{})
+	assert.ErrorIs(t, err, ErrInvalidResource)
+	_, err = list.CreateMember(t.Context(), &MemberRequest{EmailAddress: "a@b.c"})
+	assert.ErrorIs(t, err, ErrInvalidResource)
+	assert.Len(t, bodies, 4)
+}
+
+func TestResponsesAreNotValidators(t *testing.T) {
+	for _, v := range []interface{}{&ListResponse{}, &InterestCategory{}, &Segment{}, &WebHook{}} {
+		_, ok := v.(Validator)
+		assert.False(t, ok, "%T", v)
+	}
+}
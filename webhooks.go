@@ -2,7 +2,7 @@ package gochimp3
 
 import (
 	"context"
-	"fmt"
+	"encoding/json"
 )
 
 const (
@@ -14,6 +14,13 @@ type ListOfWebHooks struct {
 	baseList
 	ListID   string    `json:"list_id"`
 	WebHooks []WebHook `json:"webhooks"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *ListOfWebHooks) UnmarshalJSON(data []byte) error {
+	type alias ListOfWebHooks
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type WebHookRequest struct {
@@ -22,17 +29,48 @@ type WebHookRequest struct {
 	Sources HookSources `json:"sources"`
 }
 
+// Validate checks req for creating a webhook. Updates only check the fields
+// they set.
+func (req WebHookRequest) Validate() error {
+	var errs fieldErrors
+	errs.absoluteURL("url", req.URL)
+	return errs.err()
+}
+
+func (req WebHookRequest) validatePartial() error {
+	var errs fieldErrors
+	if req.URL != "" {
+		errs.absoluteURL("url", req.URL)
+	}
+	return errs.err()
+}
+
 type WebHook struct {
 	WebHookRequest
+	noValidate
 	ID     string `json:"id"`
 	ListID string `json:"list_id"`
 	withLinks
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *WebHook) UnmarshalJSON(data []byte) error {
+	type alias WebHook
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type HookSources struct {
 	User  bool `json:"user"`
 	Admin bool `json:"admin"`
 	API   bool `json:"api"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *HookSources) UnmarshalJSON(data []byte) error {
+	type alias HookSources
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 type HookEvents struct {
@@ -42,6 +80,13 @@ type HookEvents struct {
 	Cleaned     bool `json:"cleaned"`
 	Upemail     bool `json:"upemail"`
 	Campaign    bool `json:"campaign"`
+
+	Extra map[string]json.RawMessage `json:"-"`
+}
+
+func (v *HookEvents) UnmarshalJSON(data []byte) error {
+	type alias HookEvents
+	return unmarshalWithExtra(data, (*alias)(v), &v.Extra)
 }
 
 func (list *ListResponse) CreateWebHooks(ctx context.Context, body *WebHookRequest) (*WebHook, error) {
@@ -49,10 +94,10 @@ func (list *ListResponse) CreateWebHooks(ctx context.Context, body *WebHookReque
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(webhooks_path, list.ID)
+	op := newOperation("ListResponse.CreateWebHooks", "POST", webhooks_path, list.ID)
 	response := new(WebHook)
 
-	return response, list.api.request(ctx, "POST", endpoint, nil, &body, response)
+	return response, list.api.do(ctx, op, nil, &body, response)
 }
 
 func (list *ListResponse) UpdateWebHook(ctx context.Context, id string, body *WebHookRequest) (*WebHook, error) {
@@ -60,10 +105,10 @@ func (list *ListResponse) UpdateWebHook(ctx context.Context, id string, body *We
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_webhook_path, list.ID, id)
+	op := newOperation("ListResponse.UpdateWebHook", "PATCH", single_webhook_path, list.ID, id)
 	response := new(WebHook)
 
-	return response, list.api.request(ctx, "PATCH", endpoint, nil, &body, response)
+	return response, list.api.do(ctx, op, nil, &body, response)
 }
 
 // TODO - does this take filters? undocumented
@@ -73,10 +118,10 @@ func (list *ListResponse) GetWebHooks(ctx context.Context) (*ListOfWebHooks, err
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(webhooks_path, list.ID)
+	op := newOperation("ListResponse.GetWebHooks", "GET", webhooks_path, list.ID)
 	response := new(ListOfWebHooks)
 
-	return response, list.api.request(ctx, "GET", endpoint, nil, nil, response)
+	return response, list.api.do(ctx, op, nil, nil, response)
 }
 
 func (list *ListResponse) GetWebHook(ctx context.Context, id string) (*WebHook, error) {
@@ -84,10 +129,10 @@ func (list *ListResponse) GetWebHook(ctx context.Context, id string) (*WebHook,
 		return nil, err
 	}
 
-	endpoint := fmt.Sprintf(single_webhook_path, list.ID, id)
+	op := newOperation("ListResponse.GetWebHook", "GET", single_webhook_path, list.ID, id)
 	response := new(WebHook)
 
-	return response, list.api.request(ctx, "GET", endpoint, nil, nil, response)
+	return response, list.api.do(ctx, op, nil, nil, response)
 }
 
 func (list *ListResponse) DeleteWebHook(ctx context.Context, id string) (bool, error) {
@@ -95,6 +140,6 @@ func (list *ListResponse) DeleteWebHook(ctx context.Context, id string) (bool, e
 		return false, err
 	}
 
-	endpoint := fmt.Sprintf(single_webhook_path, list.ID, id)
-	return list.api.requestOk(ctx, "DELETE", endpoint)
+	op := newOperation("ListResponse.DeleteWebHook", "DELETE", single_webhook_path, list.ID, id)
+	return list.api.doOk(ctx, op)
 }